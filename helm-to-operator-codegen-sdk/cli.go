@@ -0,0 +1,558 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"helm_to_controller/packages/common"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// Exit-Codes of the CLI (Other than 0: Success & 1: Failure)
+const (
+	exitCodeResourcesSkipped = 2 // Some resources couldn't be converted to go-code (Go-File is still written)
+	exitCodeOutputDiffers    = 3 // diff: The existing Go-File differs from the go-code generated now
+	exitCodeRoundTripDiffers = 4 // verify --round-trip: The objects of the go-code differ from the input-resources
+)
+
+/*
+Error carrying the exit-code of the CLI
+*/
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+/*
+Options shared by generate, verify, diff & list-kinds, Set by the flags or by the config-file (--config)
+*/
+type generateOptions struct {
+	input             string
+	outputDir         string
+	fileName          string
+	splitByKind       bool
+	fieldManager      string
+	packageName       string
+	allowSkipped      bool
+	crdPaths          []string
+	goTypesConfig     string
+	typeCheck         bool
+	roundTrip         bool
+	valuesTypes       bool
+	stdin             io.Reader
+	helmYamlConvertor common.HelmYamlConvertor
+}
+
+/*
+Yaml-Content to be converted, along with its source (File-Path, Template-Path or <stdin>)
+*/
+type yamlInput struct {
+	source string
+	data   []byte
+}
+
+func newRootCommand() *cobra.Command {
+	var logLevel, configFile string
+	rootCmd := &cobra.Command{
+		Use:   "helm-to-operator-codegen-sdk",
+		Short: "Converts Helm-Charts, Kustomizations and Kubernetes-Manifests to Go-Code, to be plugged into a Kubernetes-Operator",
+		Long: `Converts Helm-Charts, Kustomizations and Kubernetes-Manifests to Go-Code, to be plugged into a Kubernetes-Operator
+
+Exit-Codes: 0 (Success), 1 (Failure), 2 (Some resources couldn't be converted), 3 (diff: Go-File differs), 4 (verify --round-trip: Objects differ)`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// The log-level is applied after the config-file, Since the config-file can set it as well
+			if configFile != "" {
+				if err := applyConfigFile(cmd, configFile); err != nil {
+					return err
+				}
+			}
+			ll, err := logrus.ParseLevel(logLevel)
+			if err != nil {
+				return err
+			}
+			logrus.SetLevel(ll)
+			return nil
+		},
+	}
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Logging-level: debug, info, warn, error, fatal")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config-file (yaml) containing the flags as keys (Example: namespace: free5gcns), Flags provided on the command-line take precedence")
+
+	rootCmd.AddCommand(newGenerateCommand(), newVerifyCommand(), newDiffCommand(), newListKindsCommand(), newKrmFunctionCommand())
+	return rootCmd
+}
+
+/*
+Adds the flags of generateOptions to the command
+*/
+func addGenerateFlags(cmd *cobra.Command, opts *generateOptions) {
+	flags := cmd.Flags()
+	flags.StringVar(&opts.input, "input", "inputs", "Helm-Chart (directory, archive, oci:// reference or chart-name in --repo), Kustomization, Manifests or - (stdin), Same as the positional argument")
+	flags.StringVarP(&opts.helmYamlConvertor.Namespace, "namespace", "n", "", "Namespace of the resources (Used by the helm-chart & the ApplyAll/CreateAll/DeleteAll of the go-code)")
+	flags.StringVarP(&opts.outputDir, "output-dir", "o", "outputs", "Directory of the generated Go-File(s)")
+	flags.StringVar(&opts.fileName, "file-name", "generated_code.go", "Name of the generated Go-File")
+	flags.BoolVar(&opts.splitByKind, "split-by-kind", false, "Writes the go-code of each kind to a separate file (service.go, deployment.go, ...), The helper-functions are written to --file-name")
+	flags.StringVar(&opts.fieldManager, "field-manager", "helm-to-operator-codegen-sdk", "Field-Manager of the server-side apply of ApplyAll in the go-code")
+	flags.StringVar(&opts.packageName, "package", "controller", "Package-name of the generated Go-File(s)")
+	flags.BoolVar(&opts.allowSkipped, "allow-skipped", false, "Exit with 0, even if some resources couldn't be converted")
+	flags.StringArrayVar(&opts.crdPaths, "crd", nil, "CRD-file (or directory of CRD-files), whose Custom-Resources are converted to typed go-structs (Can be specified multiple times), The CRDs of the input are used as well")
+	flags.StringVar(&opts.goTypesConfig, "go-types", "", "Config-file (yaml) mapping the Third-Party Kinds (apiVersion & kind) to the Go-Types of their published Go-Packages (goPackage & goType), Which are used instead of unstructured.Unstructured")
+	flags.BoolVar(&opts.typeCheck, "type-check", true, "Type-Checks the go-code of each resource and the generated go-file (Using the k8s API packages compiled into the sdk, And the go-module of --go-types), The resources whose go-code doesn't compile are skipped")
+	flags.BoolVar(&opts.valuesTypes, "values-types", false, "Generates values_types.go: The kubebuilder API-Type (<Chart>Spec) whose fields are the values of the helm-chart (Inferred from values.yaml & values.schema.json), Along with the defaults & validations as markers")
+	flags.StringVar(&opts.helmYamlConvertor.ReleaseName, "release-name", "release-name", "Release-name used while rendering the helm-chart")
+	flags.StringArrayVarP(&opts.helmYamlConvertor.ValueFiles, "values", "f", nil, "Values-file of the helm-chart (Can be specified multiple times)")
+	flags.StringArrayVar(&opts.helmYamlConvertor.Values, "set", nil, "Set values of the helm-chart (key1=val1,key2=val2)")
+	flags.StringArrayVar(&opts.helmYamlConvertor.StringValues, "set-string", nil, "Set STRING values of the helm-chart (key1=val1,key2=val2)")
+	flags.StringArrayVar(&opts.helmYamlConvertor.FileValues, "set-file", nil, "Set values of the helm-chart from files (key1=path1,key2=path2)")
+	flags.StringVar(&opts.helmYamlConvertor.ChartRepoURL, "repo", "", "URL of the chart-repository, The input is then the chart-name")
+	flags.StringVar(&opts.helmYamlConvertor.ChartVersion, "version", "", "Version (or version-constraint) of the remote chart, Defaults to latest")
+	flags.StringVar(&opts.helmYamlConvertor.ChartDigest, "digest", "", "Pins the remote chart-archive to the digest (sha256:<hex>)")
+	flags.StringVar(&opts.helmYamlConvertor.RepoUsername, "username", "", "Username of the chart-repository (--repo)")
+	flags.StringVar(&opts.helmYamlConvertor.RepoPassword, "password", "", "Password of the chart-repository (--repo)")
+	flags.StringVar(&opts.helmYamlConvertor.RegistryConfig, "registry-config", "", "Docker config-file containing the credentials of OCI registries")
+	flags.StringVar(&opts.helmYamlConvertor.CacheDir, "cache-dir", "", "Cache-directory of the pulled remote charts")
+	cmd.Args = cobra.MaximumNArgs(1)
+}
+
+/*
+Sets the flags (which are not set on the command-line) from the config-file
+The keys of the config-file are the flag-names, Example:
+
+	input: common/tests/test-helmCharts/hello-world
+	namespace: free5gcns
+	values: [values-site-a.yaml]
+	output-dir: controllers
+	file-name: generated_code.go
+*/
+func applyConfigFile(cmd *cobra.Command, configFile string) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the config-file %s| %w", configFile, err)
+	}
+	config := map[string]any{}
+	if err := k8syaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("unable to parse the config-file %s| %w", configFile, err)
+	}
+	for key, value := range config {
+		flag := cmd.Flags().Lookup(key)
+		if flag == nil {
+			return fmt.Errorf("unknown key %q in the config-file %s", key, configFile)
+		}
+		if flag.Changed {
+			continue // Command-line takes precedence
+		}
+		values, isList := value.([]any)
+		if !isList {
+			values = []any{value}
+		}
+		for _, val := range values {
+			if err := cmd.Flags().Set(key, fmt.Sprint(val)); err != nil {
+				return fmt.Errorf("invalid value of %q in the config-file %s| %w", key, configFile, err)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+Sets the input from the positional argument (Takes precedence over --input)
+*/
+func (opts *generateOptions) setInput(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		opts.input = args[0]
+	}
+	opts.stdin = cmd.InOrStdin()
+}
+
+/*
+Collects the Yaml-Content to convert, based on the input-type:
+- (stdin), Kustomization-Directory, Manifests (Directory without Chart.yaml, or a single file), Otherwise Helm-Chart
+*/
+func (opts *generateOptions) collectYamls() ([]yamlInput, error) {
+	helmYamlConvertor := &opts.helmYamlConvertor
+	switch {
+	case opts.input == "-":
+		logrus.Info(" ----------------- Reading Manifests from Stdin --------------------------")
+		data, err := io.ReadAll(opts.stdin)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the manifests from stdin| %w", err)
+		}
+		return []yamlInput{{source: "<stdin>", data: data}}, nil
+	case helmYamlConvertor.ChartRepoURL == "" && common.IsKustomization(opts.input):
+		var kustomizeYamlConvertor = common.KustomizeYamlConvertor{KustomizationPath: opts.input}
+		data, err := kustomizeYamlConvertor.RenderManifests()
+		if err != nil {
+			return nil, fmt.Errorf("unable to run the kustomization| %w", err)
+		}
+		return []yamlInput{{source: opts.input, data: data}}, nil
+	case helmYamlConvertor.ChartRepoURL == "" && isManifestInput(opts.input):
+		logrus.Info(" ----------------- Reading Manifests from ", opts.input, " --------------------------")
+		var yamlInputs []yamlInput
+		for _, yamlfile := range common.RecursiveListManifests(opts.input) {
+			data, err := common.GetFileContents(yamlfile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read the yaml-file %s| %w", yamlfile, err)
+			}
+			yamlInputs = append(yamlInputs, yamlInput{source: yamlfile, data: data})
+		}
+		return yamlInputs, nil
+	default:
+		logrus.Info(" ----------------- Converting Helm to Yaml --------------------------")
+		helmYamlConvertor.Chartpath = opts.input
+		namespace := helmYamlConvertor.Namespace
+		manifests, err := helmYamlConvertor.RenderManifests()
+		helmYamlConvertor.Namespace = namespace // RenderManifests defaults it to "default"
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert helm to yamls| %w", err)
+		}
+		var yamlInputs []yamlInput
+		for sourcePath, manifest := range manifests {
+			yamlInputs = append(yamlInputs, yamlInput{source: sourcePath, data: []byte(manifest)})
+		}
+		sort.Slice(yamlInputs, func(i, j int) bool { return yamlInputs[i].source < yamlInputs[j].source })
+		return yamlInputs, nil
+	}
+}
+
+/*
+Converts the input to go-code, and returns the Go-File (without writing it)
+*/
+func (opts *generateOptions) generate() (*common.GoFile, *resourceConverter, error) {
+	yamlInputs, err := opts.collectYamls()
+	if err != nil {
+		return nil, nil, err
+	}
+	resourceConverterObj, err := opts.newResourceConverter(yamlInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.typeCheck {
+		if err := resourceConverterObj.enableTypeCheck(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if opts.roundTrip {
+		resourceConverterObj.enableRoundTrip()
+	}
+	for _, yamlInputObj := range yamlInputs {
+		logrus.Info("CurFile --> | ", yamlInputObj.source)
+		resourceConverterObj.convertYaml(yamlInputObj.source, yamlInputObj.data)
+	}
+
+	var goFileObj = common.GoFile{Namespace: opts.helmYamlConvertor.Namespace, PackageName: opts.packageName,
+		OutputDir: opts.outputDir, FileName: opts.fileName, SplitByKind: opts.splitByKind, FieldManager: opts.fieldManager}
+	goFileObj.Intialise(runtimeSupportKinds)
+	goFileObj.Imports = resourceConverterObj.goTypeConverterObj.GetImports()
+	if opts.valuesTypes {
+		if goFileObj.ValuesTypes, err = opts.getValuesTypes(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := resourceConverterObj.generateGoFile(&goFileObj); err != nil {
+		return nil, nil, err
+	}
+	return &goFileObj, resourceConverterObj, nil
+}
+
+/*
+Returns the go-code of the kubebuilder API-Type of the values of the helm-chart (--values-types), Empty if the input is not a helm-chart
+*/
+func (opts *generateOptions) getValuesTypes() (string, error) {
+	if opts.helmYamlConvertor.Chart == nil {
+		logrus.Warn("--values-types is supported only for helm-charts (Ignored)| Input : ", opts.input)
+		return "", nil
+	}
+	var valuesStructConverterObj common.ValuesStructConverter
+	valuesTypes, err := valuesStructConverterObj.Convert(opts.helmYamlConvertor.Chart)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate the values-types| %w", err)
+	}
+	return valuesTypes, nil
+}
+
+/*
+Returns the resourceConverter for the input, Along with the Go-Types of --go-types and the CRDs of the input & --crd
+*/
+func (opts *generateOptions) newResourceConverter(yamlInputs []yamlInput) (*resourceConverter, error) {
+	var resourceConverterObj = newResourceConverter()
+	if opts.goTypesConfig != "" {
+		if err := resourceConverterObj.addGoTypes(opts.goTypesConfig); err != nil {
+			return nil, fmt.Errorf("unable to load the go-types| %w", err)
+		}
+	}
+	if err := opts.addCrds(resourceConverterObj, yamlInputs); err != nil {
+		return nil, err
+	}
+	return resourceConverterObj, nil
+}
+
+/*
+Adds the CRDs of the input and of --crd to the resourceConverter, So that their Custom-Resources are converted to typed go-structs
+*/
+func (opts *generateOptions) addCrds(resourceConverterObj *resourceConverter, yamlInputs []yamlInput) error {
+	for _, crdPath := range opts.crdPaths {
+		for _, crdFile := range common.RecursiveListManifests(crdPath) {
+			data, err := common.GetFileContents(crdFile)
+			if err != nil {
+				return fmt.Errorf("unable to read the CRD-file %s| %w", crdFile, err)
+			}
+			resourceConverterObj.addCrds(crdFile, data)
+		}
+	}
+	for _, yamlInputObj := range yamlInputs {
+		resourceConverterObj.addCrds(yamlInputObj.source, yamlInputObj.data)
+	}
+	return nil
+}
+
+/*
+Logs the summary of the conversion, and returns exitError if any resource is skipped (unless --allow-skipped)
+*/
+func (opts *generateOptions) checkSkipped(resourceConverterObj *resourceConverter) error {
+	logrus.Info("----------------- Summary ---------------------------------")
+	for gvk, resourceType := range resourceConverterObj.getResourceTypes() {
+		logrus.Info(resourceType, "\t\t |", len(resourceConverterObj.gocodes[gvk]))
+	}
+	for _, skipped := range resourceConverterObj.skipped {
+		logrus.Warn(fmt.Sprintf("Skipped Resource| Kind : %s| Name : %s| Source : %s| Reason : %s", skipped.gvk.Kind, skipped.name, skipped.source, skipped.reason))
+	}
+	if len(resourceConverterObj.skipped) != 0 && !opts.allowSkipped {
+		return &exitError{code: exitCodeResourcesSkipped, err: fmt.Errorf("%d resources couldn't be converted to go-code", len(resourceConverterObj.skipped))}
+	}
+	return nil
+}
+
+func newGenerateCommand() *cobra.Command {
+	opts := &generateOptions{}
+	cmd := &cobra.Command{
+		Use:   "generate [input]",
+		Short: "Generates the Go-File from a Helm-Chart, Kustomization, Manifests or - (stdin), Defaults to inputs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.setInput(cmd, args)
+			goFileObj, resourceConverterObj, err := opts.generate()
+			if err != nil {
+				return err
+			}
+			logrus.Info("----------------- Writing GO Code ---------------------------------")
+			if err := goFileObj.WriteToFile(); err != nil {
+				return err
+			}
+			return opts.checkSkipped(resourceConverterObj)
+		},
+	}
+	addGenerateFlags(cmd, opts)
+	return cmd
+}
+
+func newVerifyCommand() *cobra.Command {
+	opts := &generateOptions{}
+	cmd := &cobra.Command{
+		Use:   "verify [input]",
+		Short: "Checks that all the resources of the input can be converted, without writing the Go-File",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.setInput(cmd, args)
+			_, resourceConverterObj, err := opts.generate()
+			if err != nil {
+				return err
+			}
+			if err := opts.checkSkipped(resourceConverterObj); err != nil {
+				return err
+			}
+			return checkRoundTrips(resourceConverterObj)
+		},
+	}
+	addGenerateFlags(cmd, opts)
+	cmd.Flags().BoolVar(&opts.roundTrip, "round-trip", false, "Evaluates the go-code of each resource and compares the resulting object with the input-resource, Every field that differs is reported")
+	return cmd
+}
+
+/*
+Logs the differences found by the round-trip verification, and returns exitError if the object of any resource differs from its input
+*/
+func checkRoundTrips(resourceConverterObj *resourceConverter) error {
+	differing, notVerified, dropping := 0, 0, 0
+	for _, roundTrip := range resourceConverterObj.roundTrips {
+		if !roundTrip.verified {
+			notVerified++
+			logrus.Warn(fmt.Sprintf("Round-Trip Not Verified| Kind : %s| Name : %s| Source : %s| Reason : %s", roundTrip.gvk.Kind, roundTrip.name, roundTrip.source, roundTrip.reason))
+			continue
+		}
+		if len(roundTrip.dropped) != 0 {
+			dropping++
+			logrus.Info(fmt.Sprintf("Round-Trip Intentionally Dropped (Helm-Specific-Labels)| Kind : %s| Name : %s| Source : %s", roundTrip.gvk.Kind, roundTrip.name, roundTrip.source))
+			for _, dropped := range roundTrip.dropped {
+				logrus.Info("\t ", dropped)
+			}
+		}
+		if len(roundTrip.diffs) == 0 {
+			continue
+		}
+		differing++
+		logrus.Warn(fmt.Sprintf("Round-Trip Differs| Kind : %s| Name : %s| Source : %s", roundTrip.gvk.Kind, roundTrip.name, roundTrip.source))
+		for _, diff := range roundTrip.diffs {
+			logrus.Warn("\t ", diff)
+		}
+	}
+	logrus.Info(fmt.Sprintf("Round-Trip| Verified : %d| Differing : %d| Intentionally Dropped : %d| Not Verified : %d",
+		len(resourceConverterObj.roundTrips)-notVerified, differing, dropping, notVerified))
+	if differing != 0 {
+		return &exitError{code: exitCodeRoundTripDiffers, err: fmt.Errorf("the go-code of %d resources doesn't reproduce the input", differing)}
+	}
+	return nil
+}
+
+func newDiffCommand() *cobra.Command {
+	opts := &generateOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff [input]",
+		Short: "Shows the unified-diff between the existing Go-File(s) (in --output-dir) and the go-code generated now",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.setInput(cmd, args)
+			goFileObj, resourceConverterObj, err := opts.generate()
+			if err != nil {
+				return err
+			}
+			fileNames := make([]string, 0, len(goFileObj.Files))
+			for fileName := range goFileObj.Files {
+				fileNames = append(fileNames, fileName)
+			}
+			sort.Strings(fileNames)
+			outdatedFiles := []string{}
+			for _, fileName := range fileNames {
+				outputPath := goFileObj.GetOutputPath(fileName)
+				existingContent, err := os.ReadFile(outputPath)
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					return err
+				}
+				diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+					A:        difflib.SplitLines(string(existingContent)),
+					B:        difflib.SplitLines(goFileObj.Files[fileName]),
+					FromFile: outputPath,
+					ToFile:   outputPath + " (generated)",
+					Context:  3,
+				})
+				if err != nil {
+					return err
+				}
+				if diff != "" {
+					fmt.Fprint(cmd.OutOrStdout(), diff)
+					outdatedFiles = append(outdatedFiles, outputPath)
+				}
+			}
+			if len(outdatedFiles) != 0 {
+				return &exitError{code: exitCodeOutputDiffers, err: fmt.Errorf("%s not up-to-date", strings.Join(outdatedFiles, ", "))}
+			}
+			return opts.checkSkipped(resourceConverterObj)
+		},
+	}
+	addGenerateFlags(cmd, opts)
+	return cmd
+}
+
+func newListKindsCommand() *cobra.Command {
+	opts := &generateOptions{}
+	cmd := &cobra.Command{
+		Use:   "list-kinds [input]",
+		Short: "Lists the kinds converted to typed go-structs, or the kinds found in the input (if provided)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			defer writer.Flush()
+			if len(args) == 0 && !cmd.Flags().Changed("input") {
+				supportedKinds := append([]string{}, runtimeSupportKinds...)
+				sort.Strings(supportedKinds)
+				for _, kind := range supportedKinds {
+					fmt.Fprintln(writer, kind)
+				}
+				return nil
+			}
+			opts.setInput(cmd, args)
+			yamlInputs, err := opts.collectYamls()
+			if err != nil {
+				return err
+			}
+			resourceConverterObj, err := opts.newResourceConverter(yamlInputs)
+			if err != nil {
+				return err
+			}
+			kindCount := map[string]int{}
+			kindConversion := map[string]string{}
+			addKind := func(gvk schema.GroupVersionKind, conversion string) {
+				kind := gvk.GroupVersion().String() + "/" + gvk.Kind
+				kindCount[kind]++
+				kindConversion[kind] = conversion
+			}
+			for _, yamlInputObj := range yamlInputs {
+				_, gvkList, _, unstructGvkList := handleYamlContent(yamlInputObj.data)
+				for _, gvk := range gvkList {
+					addKind(gvk, "typed")
+				}
+				for _, gvk := range unstructGvkList {
+					if resourceConverterObj.goTypeConverterObj.IsKnown(gvk) {
+						addKind(gvk, "typed (go-type)")
+					} else if resourceConverterObj.crdStructConverterObj.IsKnown(gvk) {
+						addKind(gvk, "typed (crd)")
+					} else {
+						addKind(gvk, "unstructured")
+					}
+				}
+			}
+			kinds := make([]string, 0, len(kindCount))
+			for kind := range kindCount {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			fmt.Fprintln(writer, "KIND\tCOUNT\tCONVERSION")
+			for _, kind := range kinds {
+				fmt.Fprintf(writer, "%s\t%d\t%s\n", kind, kindCount[kind], kindConversion[kind])
+			}
+			return nil
+		},
+	}
+	addGenerateFlags(cmd, opts)
+	return cmd
+}
+
+func newKrmFunctionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "krm-function",
+		Short: "Runs as KRM Function (kpt/porch): Reads the ResourceList from stdin and writes it (with the generated go-code) to stdout",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKrmFunction(cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
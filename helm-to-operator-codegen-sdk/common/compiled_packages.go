@@ -0,0 +1,333 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+// Import-Path of the ptr package, Whose To function is generic (Therefore declared explicitly, See addGenericFxns)
+const ptrPackagePath = "k8s.io/utils/ptr"
+
+/*
+Data-Types used by the generated go-code, Which are not reachable from the kinds of the scheme (Example: unstructured.Unstructured, context.Context)
+*/
+var compiledGoTypeSeeds = []reflect.Type{
+	reflect.TypeOf(unstructured.Unstructured{}), reflect.TypeOf(unstructured.UnstructuredList{}), reflect.TypeOf((*runtime.Object)(nil)).Elem(),
+	reflect.TypeOf(runtime.Scheme{}), reflect.TypeOf(runtime.RawExtension{}), reflect.TypeOf(schema.GroupVersionKind{}),
+	reflect.TypeOf((*metav1.Object)(nil)).Elem(), reflect.TypeOf(metav1.Duration{}), reflect.TypeOf(metav1.MicroTime{}), reflect.TypeOf(metav1.ListMeta{}),
+	reflect.TypeOf(apiextensionsv1.CustomResourceDefinition{}), reflect.TypeOf(apiextensionsv1.JSON{}),
+	reflect.TypeOf(resource.Quantity{}), reflect.TypeOf(intstr.IntOrString{}), reflect.TypeOf((*context.Context)(nil)).Elem(),
+	reflect.TypeOf(time.Duration(0)), reflect.TypeOf(time.Month(0)),
+}
+
+/*
+Package-Level members used by the generated go-code (Helper fxns, Type-Definitions of the CRDs & go-code of the resources), Which can't be found using reflect
+Functions are given as the function itself, Variables as a pointer to the variable, And Constants as the value (of its named data-type)
+*/
+var compiledGoPackageMembers = []struct {
+	pkgPath string
+	name    string
+	value   any
+}{
+	{"fmt", "Errorf", fmt.Errorf}, {"fmt", "Sprintf", fmt.Sprintf},
+	{"errors", "Join", errors.Join}, {"errors", "New", errors.New},
+	{"encoding/base64", "StdEncoding", &base64.StdEncoding},
+	{"encoding/json", "Marshal", json.Marshal}, {"encoding/json", "Unmarshal", json.Unmarshal},
+	{"time", "Date", time.Date}, {"time", "UTC", &time.UTC}, {"time", "Local", &time.Local},
+	{"time", "January", time.January}, {"time", "February", time.February}, {"time", "March", time.March}, {"time", "April", time.April},
+	{"time", "May", time.May}, {"time", "June", time.June}, {"time", "July", time.July}, {"time", "August", time.August},
+	{"time", "September", time.September}, {"time", "October", time.October}, {"time", "November", time.November}, {"time", "December", time.December},
+	{"time", "Nanosecond", time.Nanosecond}, {"time", "Microsecond", time.Microsecond}, {"time", "Millisecond", time.Millisecond},
+	{"time", "Second", time.Second}, {"time", "Minute", time.Minute}, {"time", "Hour", time.Hour},
+	{"k8s.io/apimachinery/pkg/api/resource", "MustParse", resource.MustParse},
+	{"k8s.io/apimachinery/pkg/util/intstr", "FromInt", intstr.FromInt}, {"k8s.io/apimachinery/pkg/util/intstr", "FromString", intstr.FromString},
+	{"k8s.io/apimachinery/pkg/util/intstr", "Int", intstr.Int}, {"k8s.io/apimachinery/pkg/util/intstr", "String", intstr.String},
+}
+
+// Version-Suffix of an Import-Path, Which is not part of the package-name (Example: gopkg.in/inf.v0)
+var importPathVersionRegex = regexp.MustCompile(`\.v[0-9]+$`)
+
+/*
+Builds the go/types packages of the data-types compiled into the SDK (Using reflect), So that the go-code can be type-checked without the go-toolchain
+Only the exported fields & methods are added (The unexported ones can't be used by the generated go-code)
+*/
+type compiledPackagesBuilder struct {
+	pkgs  map[string]*types.Package
+	named map[reflect.Type]*types.Named
+}
+
+/*
+Returns the package of the Import-Path, The package-name is the last element of the Import-Path (Example: k8s.io/api/core/v1 --> v1)
+*/
+func (obj *compiledPackagesBuilder) getPackage(pkgPath string) *types.Package {
+	if pkg, ok := obj.pkgs[pkgPath]; ok {
+		return pkg
+	}
+	pkg := types.NewPackage(pkgPath, importPathVersionRegex.ReplaceAllString(path.Base(pkgPath), ""))
+	obj.pkgs[pkgPath] = pkg
+	return pkg
+}
+
+/*
+Returns the go/types data-type of the reflect data-type, The named data-types are added to the scope of their package (along with their methods)
+*/
+func (obj *compiledPackagesBuilder) getType(t reflect.Type) types.Type {
+	if named, ok := obj.named[t]; ok {
+		return named
+	}
+	if t.Name() == "" || t.PkgPath() == "" {
+		if t.Kind() == reflect.Interface && t.Name() == "error" {
+			return types.Universe.Lookup("error").Type()
+		}
+		return obj.getUnderlying(t, nil)
+	}
+	pkg := obj.getPackage(t.PkgPath())
+	typeName := types.NewTypeName(token.NoPos, pkg, t.Name(), nil)
+	named := types.NewNamed(typeName, nil, nil)
+	obj.named[t] = named
+	pkg.Scope().Insert(typeName)
+	named.SetUnderlying(obj.getUnderlying(t, pkg))
+	if t.Kind() == reflect.Interface {
+		return named // The methods are part of the underlying interface
+	}
+
+	valueMethods := map[string]bool{}
+	for i := 0; i < t.NumMethod(); i++ {
+		valueMethods[t.Method(i).Name] = true
+	}
+	ptrType := reflect.PointerTo(t)
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		method := ptrType.Method(i)
+		if isPromotedMethod(t, method.Name) {
+			continue // The methods of the embedded fields are promoted by go/types itself
+		}
+		var recvType types.Type = types.NewPointer(named)
+		if valueMethods[method.Name] {
+			recvType = named
+		}
+		recv := types.NewVar(token.NoPos, pkg, "", recvType)
+		named.AddMethod(types.NewFunc(token.NoPos, pkg, method.Name, obj.getSignature(method.Type, recv, 1)))
+	}
+	return named
+}
+
+/*
+Returns true if the method of the struct belongs to one of its embedded fields
+*/
+func isPromotedMethod(t reflect.Type, methodName string) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		if _, ok := field.Type.MethodByName(methodName); ok {
+			return true
+		}
+		if field.Type.Kind() != reflect.Ptr && field.Type.Kind() != reflect.Interface {
+			if _, ok := reflect.PointerTo(field.Type).MethodByName(methodName); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+Returns the go/types signature of the reflect function-type, skipParams: Number of the leading parameters which are the receiver (Methods)
+*/
+func (obj *compiledPackagesBuilder) getSignature(t reflect.Type, recv *types.Var, skipParams int) *types.Signature {
+	var params, results []*types.Var
+	for i := skipParams; i < t.NumIn(); i++ {
+		params = append(params, types.NewParam(token.NoPos, nil, "", obj.getType(t.In(i))))
+	}
+	for i := 0; i < t.NumOut(); i++ {
+		results = append(results, types.NewParam(token.NoPos, nil, "", obj.getType(t.Out(i))))
+	}
+	return types.NewSignatureType(recv, nil, nil, types.NewTuple(params...), types.NewTuple(results...), t.IsVariadic())
+}
+
+/*
+Returns the go/types data-type of the structure of the reflect data-type (The underlying data-type of the named data-types)
+pkg: Package of the named data-type ("" for the unnamed data-types), Which the fields belong to
+*/
+func (obj *compiledPackagesBuilder) getUnderlying(t reflect.Type, pkg *types.Package) types.Type {
+	switch t.Kind() {
+	case reflect.Bool:
+		return types.Typ[types.Bool]
+	case reflect.Int:
+		return types.Typ[types.Int]
+	case reflect.Int8:
+		return types.Typ[types.Int8]
+	case reflect.Int16:
+		return types.Typ[types.Int16]
+	case reflect.Int32:
+		return types.Typ[types.Int32]
+	case reflect.Int64:
+		return types.Typ[types.Int64]
+	case reflect.Uint:
+		return types.Typ[types.Uint]
+	case reflect.Uint8:
+		return types.Typ[types.Uint8]
+	case reflect.Uint16:
+		return types.Typ[types.Uint16]
+	case reflect.Uint32:
+		return types.Typ[types.Uint32]
+	case reflect.Uint64:
+		return types.Typ[types.Uint64]
+	case reflect.Uintptr:
+		return types.Typ[types.Uintptr]
+	case reflect.Float32:
+		return types.Typ[types.Float32]
+	case reflect.Float64:
+		return types.Typ[types.Float64]
+	case reflect.Complex64:
+		return types.Typ[types.Complex64]
+	case reflect.Complex128:
+		return types.Typ[types.Complex128]
+	case reflect.String:
+		return types.Typ[types.String]
+	case reflect.UnsafePointer:
+		return types.Typ[types.UnsafePointer]
+	case reflect.Ptr:
+		return types.NewPointer(obj.getType(t.Elem()))
+	case reflect.Slice:
+		return types.NewSlice(obj.getType(t.Elem()))
+	case reflect.Array:
+		return types.NewArray(obj.getType(t.Elem()), int64(t.Len()))
+	case reflect.Map:
+		return types.NewMap(obj.getType(t.Key()), obj.getType(t.Elem()))
+	case reflect.Chan:
+		chanDir := types.SendRecv
+		if t.ChanDir() == reflect.SendDir {
+			chanDir = types.SendOnly
+		} else if t.ChanDir() == reflect.RecvDir {
+			chanDir = types.RecvOnly
+		}
+		return types.NewChan(chanDir, obj.getType(t.Elem()))
+	case reflect.Func:
+		return obj.getSignature(t, nil, 0)
+	case reflect.Interface:
+		var methods []*types.Func
+		for i := 0; i < t.NumMethod(); i++ {
+			method := t.Method(i)
+			methodPkg := pkg
+			if method.PkgPath != "" { // Unexported method
+				methodPkg = obj.getPackage(method.PkgPath)
+			}
+			methods = append(methods, types.NewFunc(token.NoPos, methodPkg, method.Name, obj.getSignature(method.Type, nil, 0)))
+		}
+		return types.NewInterfaceType(methods, nil).Complete()
+	case reflect.Struct:
+		var fields []*types.Var
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fields = append(fields, types.NewField(token.NoPos, pkg, field.Name, obj.getType(field.Type), field.Anonymous))
+		}
+		return types.NewStruct(fields, nil)
+	}
+	return types.NewInterfaceType(nil, nil).Complete()
+}
+
+/*
+Adds the package-level member (compiledGoPackageMembers) to the scope of its package
+*/
+func (obj *compiledPackagesBuilder) addMember(pkgPath string, name string, value any) {
+	pkg := obj.getPackage(pkgPath)
+	valueType := reflect.TypeOf(value)
+	switch valueType.Kind() {
+	case reflect.Func:
+		pkg.Scope().Insert(types.NewFunc(token.NoPos, pkg, name, obj.getSignature(valueType, nil, 0)))
+	case reflect.Ptr:
+		pkg.Scope().Insert(types.NewVar(token.NoPos, pkg, name, obj.getType(valueType.Elem())))
+	default:
+		constValue := reflect.ValueOf(value)
+		var constVal constant.Value
+		switch valueType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			constVal = constant.MakeInt64(constValue.Int())
+		case reflect.String:
+			constVal = constant.MakeString(constValue.String())
+		case reflect.Bool:
+			constVal = constant.MakeBool(constValue.Bool())
+		default:
+			constVal = constant.MakeUnknown()
+		}
+		pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, name, obj.getType(valueType), constVal))
+	}
+}
+
+/*
+Adds the generic fxns used by the generated go-code, Since reflect doesn't know about the type-parameters
+ptr.To: func To[T any](v T) *T
+*/
+func (obj *compiledPackagesBuilder) addGenericFxns() {
+	pkg := obj.getPackage(ptrPackagePath)
+	typeParam := types.NewTypeParam(types.NewTypeName(token.NoPos, pkg, "T", nil), types.NewInterfaceType(nil, nil).Complete())
+	signature := types.NewSignatureType(nil, nil, []*types.TypeParam{typeParam},
+		types.NewTuple(types.NewParam(token.NoPos, pkg, "v", typeParam)), types.NewTuple(types.NewParam(token.NoPos, pkg, "", types.NewPointer(typeParam))), false)
+	pkg.Scope().Insert(types.NewFunc(token.NoPos, pkg, "To", signature))
+}
+
+/*
+Returns the go/types packages of the data-types compiled into the SDK (The kinds of the scheme, Along with compiledGoTypeSeeds & compiledGoPackageMembers),
+Import-Path as Key and the package as Value, So that the generated go-code can be type-checked in-process (Without the go-toolchain or a go-module)
+*/
+var getCompiledGoPackages = sync.OnceValue(func() map[string]*types.Package {
+	builder := &compiledPackagesBuilder{pkgs: map[string]*types.Package{}, named: map[reflect.Type]*types.Named{}}
+	for _, objType := range scheme.Scheme.AllKnownTypes() {
+		builder.getType(objType)
+	}
+	for _, seed := range compiledGoTypeSeeds {
+		builder.getType(seed)
+	}
+	for _, member := range compiledGoPackageMembers {
+		builder.addMember(member.pkgPath, member.name, member.value)
+	}
+	builder.addGenericFxns()
+	for _, pkg := range builder.pkgs {
+		pkg.MarkComplete()
+	}
+	return builder.pkgs
+})
@@ -0,0 +1,621 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+/*
+Data-Types of the fields which don't have a (complete) schema (Example: x-kubernetes-preserve-unknown-fields),
+Their values are written using the UnstructStringConverter (map[string]any{...})
+*/
+var crdUntypedGoTypes = map[string]bool{"any": true, "map[string]any": true, "[]any": true}
+
+/*
+Data-Types of the scalar fields, The optional scalar fields are pointers (Example: *bool),
+So that their explicit Zero-Values (false, 0, "") are not omitted (The optional nested structs are pointers as well, So that they are omitted if not set)
+*/
+var crdScalarGoTypes = map[string]bool{"string": true, "bool": true, "int32": true, "int64": true, "float64": true}
+
+// Json-Name of the unknown-fields map in the data-types built at runtime (See getReflectType), Its keys are moved to the object it belongs to by the GoCodeEvaluator
+const crdUnknownFieldsJsonKey = "<unknown-fields>"
+
+type crdField struct {
+	goName   string
+	jsonName string
+	goType   string
+	required bool // The field is in the required-list of its object-schema, Therefore it is not omitempty
+	props    apiextensionsv1.JSONSchemaProps
+}
+
+type crdStruct struct {
+	name   string
+	fields []crdField
+	// Go-Name of the field holding the keys which are not in the schema (x-kubernetes-preserve-unknown-fields), "" if the unknown keys are not allowed
+	unknownFieldsName string
+}
+
+/*
+Go-Types of a Custom-Resource (Kind), Generated from the openAPIV3Schema of the storage-version of its CRD
+structs[0] is the Kind itself, The rest are the nested objects (Example: <Kind>Spec, <Kind>Status, <Kind>Spec<Field>)
+*/
+type crdType struct {
+	gvk     schema.GroupVersionKind
+	crdName string
+	structs []*crdStruct
+	names   map[string]bool
+}
+
+/*
+Converts the Custom-Resources (whose CRD is known) to the Intermediate-Representation (Json-Map) of the typed go-struct,
+Same as of RuntimeJsonConverter, Therefore it can be converted to go-code by JsonStringConverter
+*/
+type CrdStructConverter struct {
+	crdTypes map[schema.GroupVersionKind]*crdType
+	mutex    sync.RWMutex
+}
+
+/*
+Converts the json-name of a field to the go-name (Exported): spec --> Spec, max-size --> MaxSize, 802.1q --> X8021q
+*/
+func getCrdGoName(jsonName string) string {
+	goName := ""
+	upperNext := true
+	for _, c := range jsonName {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			c = unicode.ToUpper(c)
+			upperNext = false
+		}
+		goName += string(c)
+	}
+	if goName == "" || unicode.IsDigit(rune(goName[0])) {
+		goName = "X" + goName
+	}
+	return goName
+}
+
+/*
+Returns a name for the struct which isn't used by any other struct of the Kind
+*/
+func (obj *crdType) getUniqueName(name string) string {
+	uniqueName := name
+	for i := 2; obj.names[uniqueName]; i++ {
+		uniqueName = name + strconv.Itoa(i)
+	}
+	obj.names[uniqueName] = true
+	return uniqueName
+}
+
+/*
+Returns the go-type of the schema, The nested objects are added as new structs (named typeName)
+Example: {type: string} --> string, {type: array, items: {type: object, properties: ...}} --> []<typeName>Item
+*/
+func (obj *crdType) getGoType(typeName string, props apiextensionsv1.JSONSchemaProps) string {
+	if props.XIntOrString {
+		return "intstr.IntOrString"
+	}
+	switch props.Type {
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	case "integer":
+		if props.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "array":
+		if props.Items == nil || props.Items.Schema == nil {
+			return "[]any"
+		}
+		itemType := obj.getGoType(typeName+"Item", *props.Items.Schema)
+		if crdUntypedGoTypes[itemType] {
+			return "[]any"
+		}
+		return "[]" + itemType
+	case "object":
+		if len(props.Properties) != 0 {
+			return obj.addStruct(typeName, props, false)
+		}
+		if props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil {
+			valueType := obj.getGoType(typeName+"Value", *props.AdditionalProperties.Schema)
+			if crdUntypedGoTypes[valueType] {
+				return "map[string]any"
+			}
+			return "map[string]" + valueType
+		}
+		return "map[string]any"
+	}
+	return "any"
+}
+
+/*
+Adds the struct (and its nested structs) for the object-schema, Returns the name of the struct
+isRoot: The struct is the Kind itself, Therefore apiVersion, kind & metadata are replaced by metav1.TypeMeta & metav1.ObjectMeta
+*/
+func (obj *crdType) addStruct(typeName string, props apiextensionsv1.JSONSchemaProps, isRoot bool) string {
+	curStruct := &crdStruct{name: obj.getUniqueName(typeName)}
+	obj.structs = append(obj.structs, curStruct)
+	for _, jsonName := range sortedKeys(props.Properties) {
+		if isRoot && (jsonName == "apiVersion" || jsonName == "kind" || jsonName == "metadata") {
+			continue
+		}
+		fieldProps := props.Properties[jsonName]
+		goName := getCrdGoName(jsonName)
+		field := crdField{goName: goName, jsonName: jsonName, props: fieldProps, goType: obj.getGoType(curStruct.name+goName, fieldProps)}
+		for _, requiredName := range props.Required {
+			field.required = field.required || requiredName == jsonName
+		}
+		if !field.required && (crdScalarGoTypes[field.goType] || obj.names[field.goType]) {
+			field.goType = "*" + field.goType
+		}
+		curStruct.fields = append(curStruct.fields, field)
+	}
+	if props.XPreserveUnknownFields != nil && *props.XPreserveUnknownFields {
+		curStruct.unknownFieldsName = "UnknownFields"
+		for _, field := range curStruct.fields {
+			if field.goName == curStruct.unknownFieldsName {
+				curStruct.unknownFieldsName = "X" + curStruct.unknownFieldsName
+			}
+		}
+	}
+	return curStruct.name
+}
+
+/*
+Returns the go-code of MarshalJSON & UnmarshalJSON of the struct which preserves the unknown fields,
+So that the keys of its unknown-fields map are written (and read) along with the fields of the struct
+*/
+func (obj *crdType) getUnknownFieldsMethods(curStruct *crdStruct, isRoot bool) string {
+	knownKeys := []string{}
+	if isRoot {
+		knownKeys = append(knownKeys, `"apiVersion"`, `"kind"`, `"metadata"`)
+	}
+	for _, field := range curStruct.fields {
+		knownKeys = append(knownKeys, strconv.Quote(field.jsonName))
+	}
+	return fmt.Sprintf(`
+func (in %[1]s) MarshalJSON() ([]byte, error) {
+	type plain %[1]s
+	data, err := json.Marshal(plain(in))
+	if err != nil || len(in.%[2]s) == 0 {
+		return data, err
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	for key, value := range in.%[2]s {
+		out[key] = value
+	}
+	return json.Marshal(out)
+}
+
+func (in *%[1]s) UnmarshalJSON(data []byte) error {
+	type plain %[1]s
+	if err := json.Unmarshal(data, (*plain)(in)); err != nil {
+		return err
+	}
+	in.%[2]s = nil
+	if err := json.Unmarshal(data, &in.%[2]s); err != nil {
+		return err
+	}
+	for _, key := range []string{%[3]s} {
+		delete(in.%[2]s, key)
+	}
+	return nil
+}
+`, curStruct.name, curStruct.unknownFieldsName, strings.Join(knownKeys, ", "))
+}
+
+/*
+Returns the go-code of the type-definitions of the Kind (along with DeepCopyObject & Add<Kind>ToScheme, So that it can be used by the client)
+*/
+func (obj *crdType) getTypeDefinitions() string {
+	kind := obj.gvk.Kind
+	out := fmt.Sprintf(`
+/*
+%s is generated from the openAPIV3Schema of the CRD %s (%s)
+*/`, kind, obj.crdName, obj.gvk.GroupVersion().String())
+	for i, curStruct := range obj.structs {
+		fields := ""
+		if i == 0 {
+			fields = "\tmetav1.TypeMeta   `json:\",inline\"`\n\tmetav1.ObjectMeta `json:\"metadata,omitempty\"`\n"
+		}
+		for _, field := range curStruct.fields {
+			jsonTag := field.jsonName + ",omitempty"
+			if field.required {
+				jsonTag = field.jsonName
+			}
+			fields += fmt.Sprintf("\t%s %s `json:\"%s\"`\n", field.goName, field.goType, jsonTag)
+		}
+		if curStruct.unknownFieldsName != "" {
+			// Keys which are not in the schema (x-kubernetes-preserve-unknown-fields), Written along with the fields by MarshalJSON
+			fields += fmt.Sprintf("\t%s map[string]any `json:\"-\"`\n", curStruct.unknownFieldsName)
+		}
+		out += fmt.Sprintf("\ntype %s struct {\n%s}\n", curStruct.name, fields)
+		if curStruct.unknownFieldsName != "" {
+			out += obj.getUnknownFieldsMethods(curStruct, i == 0)
+		}
+	}
+	out += fmt.Sprintf(`
+func (in *%s) DeepCopyObject() runtime.Object {
+	out := &%s{}
+	data, _ := json.Marshal(in)
+	_ = json.Unmarshal(data, out)
+	return out
+}
+
+/*
+Registers %s (%s) to the scheme, Required by the client to create/delete it
+*/
+func Add%sToScheme(scheme *runtime.Scheme) {
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "%s", Version: "%s", Kind: "%s"}, &%s{})
+}
+`, kind, kind, kind, obj.gvk.GroupVersion().String(), kind, obj.gvk.Group, obj.gvk.Version, kind, kind)
+	return out
+}
+
+/*
+Returns the data-type of the go-type (As written in the type-definitions, Example: *int64, []DatabaseSpecUsersItem), Built at runtime using reflect,
+So that the go-code of the Custom-Resources can be evaluated by the GoCodeEvaluator (The generated MarshalJSON of the unknown-fields is replaced by crdUnknownFieldsJsonKey)
+reflectTypes: Struct-Name as Key and its data-type as Value, For the structs which are already built
+*/
+func (obj *crdType) getReflectType(goType string, reflectTypes map[string]reflect.Type) reflect.Type {
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		return reflect.PointerTo(obj.getReflectType(goType[1:], reflectTypes))
+	case strings.HasPrefix(goType, "[]"):
+		return reflect.SliceOf(obj.getReflectType(goType[2:], reflectTypes))
+	case strings.HasPrefix(goType, "map[string]"):
+		return reflect.MapOf(reflect.TypeOf(""), obj.getReflectType(goType[len("map[string]"):], reflectTypes))
+	case goType == "intstr.IntOrString":
+		return reflect.TypeOf(intstr.IntOrString{})
+	}
+	if predeclaredType, ok := predeclaredGoTypes[goType]; ok {
+		return predeclaredType
+	}
+	if structType, ok := reflectTypes[goType]; ok {
+		return structType
+	}
+	var fields []reflect.StructField
+	for i, curStruct := range obj.structs {
+		if curStruct.name != goType {
+			continue
+		}
+		if i == 0 {
+			fields = append(fields, reflect.StructField{Name: "TypeMeta", Type: reflect.TypeOf(metav1.TypeMeta{}), Tag: `json:",inline"`, Anonymous: true},
+				reflect.StructField{Name: "ObjectMeta", Type: reflect.TypeOf(metav1.ObjectMeta{}), Tag: `json:"metadata,omitempty"`})
+		}
+		for _, field := range curStruct.fields {
+			jsonTag := field.jsonName + ",omitempty"
+			if field.required {
+				jsonTag = field.jsonName
+			}
+			fields = append(fields, reflect.StructField{Name: field.goName, Type: obj.getReflectType(field.goType, reflectTypes), Tag: reflect.StructTag(fmt.Sprintf("json:%q", jsonTag))})
+		}
+		if curStruct.unknownFieldsName != "" {
+			fields = append(fields, reflect.StructField{Name: curStruct.unknownFieldsName, Type: reflect.TypeOf(map[string]any{}),
+				Tag: reflect.StructTag(fmt.Sprintf("json:%q", crdUnknownFieldsJsonKey+",omitempty"))})
+		}
+	}
+	reflectTypes[goType] = reflect.StructOf(fields)
+	return reflectTypes[goType]
+}
+
+/*
+Returns the Intermediate-Representation of the intstr.IntOrString value (int or string)
+*/
+func getIntOrStringIR(value any) (map[string]any, error) {
+	switch intOrStrVal := value.(type) {
+	case string:
+		return map[string]any{"Type": map[string]any{"type": "intstr.Type", "val": "1"},
+			"StrVal": map[string]any{"type": "string", "val": intOrStrVal}}, nil
+	case int64:
+		return map[string]any{"IntVal": map[string]any{"type": "int32", "val": strconv.FormatInt(intOrStrVal, 10)}}, nil
+	case float64:
+		if intOrStrVal == float64(int32(intOrStrVal)) {
+			return map[string]any{"IntVal": map[string]any{"type": "int32", "val": strconv.FormatInt(int64(intOrStrVal), 10)}}, nil
+		}
+	}
+	return nil, fmt.Errorf("expected int-or-string, found %v", value)
+}
+
+/*
+Returns the Intermediate-Representation of the value, as per the go-type of the field
+Struct: {"<GoName>": {"type": <go-type>, "val": <value>}}, Map: {"<key>": <value>}, Slice: [<value>], Scalars: string/bool
+*/
+func (obj *crdType) getIRValue(goType string, props apiextensionsv1.JSONSchemaProps, value any) (any, error) {
+	if elemType, isPointer := strings.CutPrefix(goType, "*"); isPointer {
+		// Optional scalar fields (Example: *bool), Their value is written using the pointer helper-functions by the JsonStringConverter
+		return obj.getIRValue(elemType, props, value)
+	}
+	switch {
+	case goType == "string":
+		strVal, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, found %v", value)
+		}
+		return strVal, nil
+	case goType == "bool":
+		boolVal, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected boolean, found %v", value)
+		}
+		return boolVal, nil
+	case goType == "int32" || goType == "int64" || goType == "float64":
+		switch numVal := value.(type) {
+		case int64:
+			return strconv.FormatInt(numVal, 10), nil
+		case float64:
+			if goType != "float64" && numVal != float64(int64(numVal)) {
+				return nil, fmt.Errorf("expected integer, found %v", value)
+			}
+			return strconv.FormatFloat(numVal, 'f', -1, 64), nil
+		}
+		return nil, fmt.Errorf("expected number, found %v", value)
+	case goType == "intstr.IntOrString":
+		return getIntOrStringIR(value)
+	case strings.HasPrefix(goType, "[]"):
+		sliceVal, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array, found %v", value)
+		}
+		out := []any{}
+		for _, item := range sliceVal {
+			irItem, err := obj.getIRValue(goType[2:], *props.Items.Schema, item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, irItem)
+		}
+		return out, nil
+	case strings.HasPrefix(goType, "map[string]"):
+		mapVal, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object, found %v", value)
+		}
+		out := map[string]any{}
+		for key, item := range mapVal {
+			irItem, err := obj.getIRValue(goType[len("map[string]"):], *props.AdditionalProperties.Schema, item)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = irItem
+		}
+		return out, nil
+	}
+	// Struct
+	mapVal, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected object, found %v", value)
+	}
+	var curStruct *crdStruct
+	for _, structObj := range obj.structs {
+		if structObj.name == goType {
+			curStruct = structObj
+		}
+	}
+	return obj.getIRStruct(curStruct, mapVal)
+}
+
+/*
+Returns the Intermediate-Representation of the struct, The fields which are not set (or null) and the empty lists/objects are omitted,
+The explicit Zero-Values of the scalar fields (false, 0, "") are kept (Same as of the pointer-fields in RuntimeJsonConverter)
+The keys which are not in the schema are added to the unknown-fields map (x-kubernetes-preserve-unknown-fields), Otherwise an error is returned
+*/
+func (obj *crdType) getIRStruct(curStruct *crdStruct, value map[string]any) (map[string]any, error) {
+	out := map[string]any{}
+	indexOffset := 0
+	if curStruct == obj.structs[0] {
+		indexOffset = 2 // The Kind itself starts with metav1.TypeMeta & metav1.ObjectMeta (See getTypeDefinitions)
+	}
+	for i, field := range curStruct.fields {
+		fieldVal, ok := value[field.jsonName]
+		if !ok || fieldVal == nil {
+			continue
+		}
+		if crdUntypedGoTypes[field.goType] {
+			if fieldRef := reflect.ValueOf(fieldVal); (fieldRef.Kind() == reflect.Map || fieldRef.Kind() == reflect.Slice) && fieldRef.Len() == 0 {
+				continue
+			}
+			// The value is written as go-code, using the UnstructStringConverter
+			unstructStringConverterObj := UnstructStringConverter{}
+			out[field.goName] = map[string]any{"type": rawGoCodeType, "val": unstructStringConverterObj.runDfsUnstruct(reflect.ValueOf(fieldVal), 3), "index": i + indexOffset}
+			continue
+		}
+		irVal, err := obj.getIRValue(field.goType, field.props, fieldVal)
+		if err != nil {
+			return nil, fmt.Errorf("field %s| %w", field.jsonName, err)
+		}
+		if irMap, isMap := irVal.(map[string]any); isMap && len(irMap) == 0 && !strings.HasPrefix(field.goType, "*") {
+			continue // Pointers to empty structs are kept (Same as of RuntimeJsonConverter, Example: status: {})
+		}
+		if irSlice, isSlice := irVal.([]any); isSlice && len(irSlice) == 0 {
+			continue
+		}
+		out[field.goName] = map[string]any{"type": field.goType, "val": irVal, "index": i + indexOffset}
+	}
+
+	knownKeys := map[string]bool{}
+	if curStruct == obj.structs[0] {
+		knownKeys = map[string]bool{"apiVersion": true, "kind": true, "metadata": true}
+	}
+	for _, field := range curStruct.fields {
+		knownKeys[field.jsonName] = true
+	}
+	unknownFields := map[string]any{}
+	for _, key := range sortedKeys(value) {
+		if knownKeys[key] {
+			continue
+		}
+		if curStruct.unknownFieldsName == "" {
+			return nil, fmt.Errorf("unknown field %s (Not in the schema of the CRD)", key)
+		}
+		unknownFields[key] = value[key]
+	}
+	if len(unknownFields) != 0 {
+		unstructStringConverterObj := UnstructStringConverter{}
+		out[curStruct.unknownFieldsName] = map[string]any{"type": rawGoCodeType, "val": unstructStringConverterObj.runDfsUnstruct(reflect.ValueOf(unknownFields), 3),
+			"index": len(curStruct.fields) + indexOffset}
+	}
+	return out, nil
+}
+
+/*
+Adds the CRD (apiextensions.k8s.io/v1 CustomResourceDefinition), So that its Custom-Resources are converted to typed go-structs
+Only the storage-version of the CRD is added (The Custom-Resources of the other versions remain unstructured)
+*/
+func (obj *CrdStructConverter) AddCrd(unstructObj unstructured.Unstructured) error {
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructObj.Object, &crd); err != nil {
+		return fmt.Errorf("unable to read the CRD %s| %w", unstructObj.GetName(), err)
+	}
+	for _, version := range crd.Spec.Versions {
+		if !version.Storage {
+			continue
+		}
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			return fmt.Errorf("CRD %s doesn't have openAPIV3Schema for the version %s", crd.Name, version.Name)
+		}
+		curCrdType := &crdType{
+			gvk:     schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind},
+			crdName: crd.Name,
+			names:   map[string]bool{},
+		}
+		curCrdType.addStruct(crd.Spec.Names.Kind, *version.Schema.OpenAPIV3Schema, true)
+		obj.mutex.Lock()
+		if obj.crdTypes == nil {
+			obj.crdTypes = map[schema.GroupVersionKind]*crdType{}
+		}
+		obj.crdTypes[curCrdType.gvk] = curCrdType
+		obj.mutex.Unlock()
+		logrus.Info("Kind | ", curCrdType.gvk.Kind, " Would Be Converted to Typed Go-Struct (From CRD ", crd.Name, ")")
+	}
+	return nil
+}
+
+/*
+Returns true if the CRD of the Kind is added (Therefore, Its Custom-Resources would be converted to typed go-structs)
+*/
+func (obj *CrdStructConverter) IsKnown(gvk schema.GroupVersionKind) bool {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	_, ok := obj.crdTypes[gvk]
+	return ok
+}
+
+/*
+Returns the go-code of the type-definitions of the Kind (Empty if the CRD of the Kind is not added)
+*/
+func (obj *CrdStructConverter) GetTypeDefinitions(gvk schema.GroupVersionKind) string {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	if curCrdType, ok := obj.crdTypes[gvk]; ok {
+		return curCrdType.getTypeDefinitions()
+	}
+	return ""
+}
+
+/*
+Returns the data-types of the go-structs of the Kind (Struct-Name as Key), Built at runtime using reflect (See getReflectType),
+So that the go-code of its Custom-Resources can be evaluated by the GoCodeEvaluator (Round-Trip Verification)
+*/
+func (obj *CrdStructConverter) GetGoTypes(gvk schema.GroupVersionKind) (goTypes map[string]reflect.Type, err error) {
+	obj.mutex.RLock()
+	curCrdType, ok := obj.crdTypes[gvk]
+	obj.mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("CRD of the Kind %s is not known", gvk.Kind)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			// reflect.StructOf panics for the data-types it doesn't support
+			goTypes, err = nil, fmt.Errorf("unable to build the go-structs of the Kind %s| %v", gvk.Kind, r)
+		}
+	}()
+	goTypes = map[string]reflect.Type{}
+	for _, curStruct := range curCrdType.structs {
+		curCrdType.getReflectType(curStruct.name, goTypes)
+	}
+	return goTypes, nil
+}
+
+/*
+Input: Custom-Resource (Unstructured) whose CRD is added
+Output: Returns the in-memory Intermediate-Representation (Json-Map) of the Custom-Resource, Same as of RuntimeJsonConverter
+Error is returned if the Custom-Resource doesn't match with the schema of its CRD
+*/
+func (obj *CrdStructConverter) Convert(unstructObj unstructured.Unstructured) (map[string]any, error) {
+	obj.mutex.RLock()
+	curCrdType, ok := obj.crdTypes[unstructObj.GroupVersionKind()]
+	obj.mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("CRD of the Kind %s is not known", unstructObj.GetKind())
+	}
+	out, err := curCrdType.getIRStruct(curCrdType.structs[0], unstructObj.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	objectMeta := metav1.ObjectMeta{}
+	if metadata, ok := unstructObj.Object["metadata"].(map[string]any); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(metadata, &objectMeta); err != nil {
+			return nil, fmt.Errorf("unable to read the metadata| %w", err)
+		}
+	}
+	runtimeJsonConverterObj := RuntimeJsonConverter{}
+	if objectMetaVal := runtimeJsonConverterObj.runDfsJsonOmitEmpty(objectMeta, 0); objectMetaVal != nil {
+		out["ObjectMeta"] = map[string]any{"type": "metav1.ObjectMeta", "val": objectMetaVal, "index": 1}
+	}
+	out["TypeMeta"] = map[string]any{"type": "metav1.TypeMeta", "val": map[string]any{
+		"Kind":       map[string]any{"type": "string", "val": unstructObj.GetKind(), "index": 0},
+		"APIVersion": map[string]any{"type": "string", "val": unstructObj.GetAPIVersion(), "index": 1},
+	}, "index": 0}
+
+	// Normalising the Map to Json-Types, Which is what JsonStringConverter expects
+	jsonString, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	var intermediateRepresentation map[string]any
+	if err := json.Unmarshal(jsonString, &intermediateRepresentation); err != nil {
+		return nil, err
+	}
+	return intermediateRepresentation, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+/*
+Reads the (multi-document) yaml-file as list of Unstructured Objects
+*/
+func readUnstructuredObjects(t *testing.T, inputFile string) []unstructured.Unstructured {
+	data, err := GetFileContents(inputFile)
+	if err != nil {
+		t.Fatalf("Unable to Load File %s| Error %s", inputFile, err)
+	}
+	var unstructObjList []unstructured.Unstructured
+	for _, doc := range strings.Split(string(data), "\n---") {
+		unstructObj := unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &unstructObj.Object); err != nil {
+			t.Fatalf("Unable to Unmarshal the yaml %s| Error %s", inputFile, err)
+		}
+		unstructObjList = append(unstructObjList, unstructObj)
+	}
+	return unstructObjList
+}
+
+func TestGetCrdGoName(t *testing.T) {
+	tests := []Tests{
+		{"spec", "Spec"},
+		{"maxSize", "MaxSize"},
+		{"class-name", "ClassName"},
+		{"max_connections", "MaxConnections"},
+		{"802.1q", "X8021q"},
+	}
+	for _, test := range tests {
+		result := getCrdGoName(test.input.(string))
+		if result != test.expected.(string) {
+			t.Errorf("GetCrdGoName Failed | Input %s | Expected %s | Got %s", test.input, test.expected, result)
+		}
+	}
+}
+
+func TestCrdStructConverter(t *testing.T) {
+	var crdStructConverterObj = CrdStructConverter{}
+	for _, crd := range readUnstructuredObjects(t, "tests/test-crds/crds.yaml") {
+		if err := crdStructConverterObj.AddCrd(crd); err != nil {
+			t.Fatalf("Unable to Add the CRD %s| Error %v", crd.GetName(), err)
+		}
+	}
+	// Only the storage-version is converted to typed go-structs
+	if !crdStructConverterObj.IsKnown(schema.GroupVersionKind{Group: "example.com", Version: "v1beta1", Kind: "Database"}) {
+		t.Errorf("Storage-Version of the CRD should be known")
+	}
+	if crdStructConverterObj.IsKnown(schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "Database"}) {
+		t.Errorf("Non-Storage-Version of the CRD should not be known")
+	}
+
+	typeDefinitions := crdStructConverterObj.GetTypeDefinitions(schema.GroupVersionKind{Group: "example.com", Version: "v1beta1", Kind: "Database"})
+	// The optional scalar fields are pointers, The required fields are not omitempty
+	for _, expected := range []string{"type Database struct", "Spec *DatabaseSpec `json:\"spec,omitempty\"`", "Storage *DatabaseSpecStorage `json:\"storage,omitempty\"`", "Replicas *int32 `json:\"replicas,omitempty\"`",
+		"Count *int64 `json:\"count,omitempty\"`", "Name string `json:\"name\"`",
+		"Port intstr.IntOrString", "Settings map[string]string", "Extra map[string]any", "Users []DatabaseSpecUsersItem", "func AddDatabaseToScheme("} {
+		if !strings.Contains(typeDefinitions, expected) {
+			t.Errorf("'%s' Not Found in Type-Definitions| Got %s", expected, typeDefinitions)
+		}
+	}
+
+	customResources := readUnstructuredObjects(t, "tests/test-crds/custom-resources.yaml")
+	result, err := crdStructConverterObj.Convert(customResources[1])
+	if err != nil {
+		t.Fatalf("Unable to Convert the Custom-Resource | Error %v", err)
+	}
+	spec := result["Spec"].(map[string]any)
+	if spec["type"] != "*DatabaseSpec" {
+		t.Errorf("Type of Spec is not as expected | Got %v", spec["type"])
+	}
+	replicas := spec["val"].(map[string]any)["Replicas"]
+	if replicas.(map[string]any)["type"] != "*int32" || replicas.(map[string]any)["val"] != "3" {
+		t.Errorf("Replicas is not as expected | Got %v", replicas)
+	}
+
+	// The explicit Zero-Values of the scalar fields are kept, The fields which are not set are omitted
+	zeroValues := unstructured.Unstructured{Object: map[string]any{"apiVersion": "example.com/v1beta1", "kind": "Database", "metadata": map[string]any{"name": "zero"},
+		"spec": map[string]any{"enabled": false, "count": int64(0), "engine-version": "", "users": []any{map[string]any{"name": ""}}}}}
+	result, err = crdStructConverterObj.Convert(zeroValues)
+	if err != nil {
+		t.Fatalf("Unable to Convert the Custom-Resource | Error %v", err)
+	}
+	zeroSpec := result["Spec"].(map[string]any)["val"].(map[string]any)
+	// The Intermediate-Representation is normalised to Json-Types, Therefore the index is float64
+	tests := []Tests{
+		{"Enabled", map[string]any{"type": "*bool", "val": false, "index": 1.0}},
+		{"Count", map[string]any{"type": "*int64", "val": "0", "index": 0.0}},
+		{"EngineVersion", map[string]any{"type": "*string", "val": "", "index": 2.0}},
+		{"Users", map[string]any{"type": "[]DatabaseSpecUsersItem", "val": []any{map[string]any{"Name": map[string]any{"type": "string", "val": "", "index": 0.0}}}, "index": 9.0}},
+		{"Replicas", nil},
+	}
+	for _, test := range tests {
+		fieldVal, ok := zeroSpec[test.input.(string)]
+		if test.expected == nil {
+			if ok {
+				t.Errorf("Field %s is not set, It should be omitted | Got %v", test.input, fieldVal)
+			}
+		} else if !reflect.DeepEqual(fieldVal, test.expected) {
+			t.Errorf("Field %s is not as expected | Expected %v | Got %v", test.input, test.expected, fieldVal)
+		}
+	}
+
+	// The keys which are not in the schema are kept in the unknown-fields map (x-kubernetes-preserve-unknown-fields)
+	widgetTypeDefinitions := crdStructConverterObj.GetTypeDefinitions(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+	for _, expected := range []string{"UnknownFields map[string]any `json:\"-\"`", "func (in WidgetSpec) MarshalJSON() ([]byte, error)",
+		"func (in *WidgetSpec) UnmarshalJSON(data []byte) error", `for _, key := range []string{"size"}`} {
+		if !strings.Contains(widgetTypeDefinitions, expected) {
+			t.Errorf("'%s' Not Found in Type-Definitions| Got %s", expected, widgetTypeDefinitions)
+		}
+	}
+	result, err = crdStructConverterObj.Convert(customResources[2])
+	if err != nil {
+		t.Fatalf("Unable to Convert the Custom-Resource | Error %v", err)
+	}
+	widgetSpec := result["Spec"].(map[string]any)["val"].(map[string]any)
+	if !reflect.DeepEqual(widgetSpec["Size"], map[string]any{"type": "*int64", "val": "0", "index": 0.0}) {
+		t.Errorf("Size is not as expected | Got %v", widgetSpec["Size"])
+	}
+	unknownFields, _ := widgetSpec["UnknownFields"].(map[string]any)
+	if unknownFields["type"] != rawGoCodeType || unknownFields["index"] != 1.0 ||
+		!strings.Contains(unknownFields["val"].(string), `"color": "red"`) || !strings.Contains(unknownFields["val"].(string), `"a": 1`) {
+		t.Errorf("Unknown fields are not as expected | Got %v", widgetSpec["UnknownFields"])
+	}
+
+	// The go-code of the typed go-structs is evaluated using their data-types built at runtime (Round-Trip Verification)
+	goTypes, err := crdStructConverterObj.GetGoTypes(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+	if err != nil {
+		t.Fatalf("Unable to build the go-structs of Widget | Error %v", err)
+	}
+	goCodeEvaluatorObj := GoCodeEvaluator{}
+	goCodeEvaluatorObj.Intialise()
+	tests = []Tests{
+		{
+			input:    "&Widget{TypeMeta: metav1.TypeMeta{Kind: \"Widget\", APIVersion: \"example.com/v1\"}, ObjectMeta: metav1.ObjectMeta{Name: \"small\"}, Spec: &WidgetSpec{Size: int64Ptr(0), UnknownFields: map[string]any{\"color\": \"red\", \"extra\": map[string]any{\"a\": 1}}}}",
+			expected: "",
+		},
+		{
+			input:    "&Widget{TypeMeta: metav1.TypeMeta{Kind: \"Widget\", APIVersion: \"example.com/v1\"}, ObjectMeta: metav1.ObjectMeta{Name: \"small\"}, Spec: &WidgetSpec{UnknownFields: map[string]any{\"color\": \"red\", \"extra\": map[string]any{\"a\": 1}}}}",
+			expected: "spec.size: expected 0, got <missing>",
+		},
+	}
+	for _, test := range tests {
+		diffs, _, err := goCodeEvaluatorObj.WithGoTypes(goTypes).Verify(test.input.(string), &customResources[2])
+		if err != nil || strings.Join(diffs, "; ") != test.expected.(string) {
+			t.Errorf("Round-Trip Verification of Widget Failed | Expected %s | Got %v %v", test.expected, diffs, err)
+		}
+	}
+
+	// The keys which are not in the schema are not allowed otherwise
+	customResources[1].Object["spec"].(map[string]any)["color"] = "red"
+	if _, err := crdStructConverterObj.Convert(customResources[1]); err == nil || !strings.Contains(err.Error(), "field spec| unknown field color") {
+		t.Errorf("Expected error for the unknown field, Got %v", err)
+	}
+	delete(customResources[1].Object["spec"].(map[string]any), "color")
+
+	// Custom-Resource not matching with the schema of its CRD
+	customResources[1].Object["spec"].(map[string]any)["replicas"] = "three"
+	if _, err := crdStructConverterObj.Convert(customResources[1]); err == nil {
+		t.Errorf("Expected error for Custom-Resource not matching with the schema, Got nil")
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Package-Name of the go-files which are type-checked (Doesn't affect the type-check)
+const checkedGoPackageName = "generated"
+
+// Go-Module of controller-runtime, Which is imported by the generated go-file (client & controllerutil)
+const controllerRuntimeModule = "sigs.k8s.io/controller-runtime"
+
+/*
+Declarations of the controller-runtime API used by the generated go-file (v0.15+), Import-Path as Key and the go-code of the package as Value
+The sdk binary doesn't depend on controller-runtime (Only its tests do, See TestGeneratedCodeCompiles), Therefore the generated go-file is type-checked against these declarations
+*/
+var controllerRuntimeDeclarations = map[string]string{
+	controllerRuntimeModule + "/pkg/client": `package client
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+type Patch interface{}
+
+type CreateOption interface{}
+
+type DeleteOption interface{}
+
+type PatchOption interface{}
+
+type DeleteAllOfOption interface{}
+
+type Client interface {
+	Create(ctx context.Context, obj Object, opts ...CreateOption) error
+	Delete(ctx context.Context, obj Object, opts ...DeleteOption) error
+	Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error
+	DeleteAllOf(ctx context.Context, obj Object, opts ...DeleteAllOfOption) error
+	IsObjectNamespaced(obj runtime.Object) (bool, error)
+}
+
+type FieldOwner string
+
+type MatchingLabels map[string]string
+
+var Apply Patch
+
+var ForceOwnership PatchOption
+
+func IgnoreNotFound(err error) error { return err }
+`,
+	controllerRuntimeModule + "/pkg/controller/controllerutil": `package controllerutil
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func SetControllerReference(owner, object metav1.Object, scheme *runtime.Scheme) error { return nil }
+`,
+}
+
+// Maximum number of type-errors reported for a resource
+const maxReportedTypeErrors = 3
+
+/*
+Go-Packages loaded by the GoCodeCheckers, "<Directory of the go-module>|<Import-Paths>" as Key and the loaded packages (Import-Path as Key) as Value
+Loading the k8s API packages takes a few seconds, Therefore they are loaded only once
+The mutex also serialises the type-checks, Since they share the loaded packages
+*/
+var checkedGoPackages = struct {
+	sync.Mutex
+	pkgs map[string]map[string]*types.Package
+}{pkgs: map[string]map[string]*types.Package{}}
+
+/*
+Type-Checks (go/types) the generated go-code of the resources, So that the go-code which wouldn't compile is not written to the go-file
+The imported Go-Packages (k8s API packages of goFileImports) are built from the data-types compiled into the SDK (getCompiledGoPackages),
+Only the Go-Packages of the Go-Types (imports) need golang.org/x/tools/go/packages, As resolved in the go-module directory (Same as of GoTypeConverter)
+*/
+type GoCodeChecker struct {
+	imports map[string]string         // Alias as Key and Import-Path as Value, Imports other than goFileImports (Same as GoFile.Imports)
+	pkgs    map[string]*types.Package // Import-Path as Key and the loaded package as Value (Set By Intialise)
+}
+
+/*
+Intialises the GoCodeChecker for the go-module directory ("" for the current directory) and the imports other than goFileImports (Go-Packages of the Go-Types)
+All the Go-Packages which can be imported by the go-code (goFileImports & imports) are loaded upfront, So that a broken setup is reported once
+Returns an error, If the Go-Packages of the Go-Types can't be loaded (Example: The go-toolchain is not installed, or the directory is not part of a go-module requiring k8s.io/api)
+*/
+func (obj *GoCodeChecker) Intialise(goModuleDir string, imports map[string]string) error {
+	absGoModuleDir, err := filepath.Abs(goModuleDir)
+	if err != nil {
+		return err
+	}
+	obj.imports = imports
+	importPaths := []string{"encoding/base64", "fmt"} // Used by the helper fxns
+	for _, goImport := range goFileImports {
+		if !strings.HasPrefix(goImport.path, controllerRuntimeModule) { // controller-runtime is only used by the master fxns (ApplyAll, ...), Not by the go-code of the resources
+			importPaths = append(importPaths, goImport.path)
+		}
+	}
+	for _, importPath := range imports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+	cacheKey := absGoModuleDir + "|" + strings.Join(importPaths, ",")
+	if len(imports) == 0 {
+		cacheKey = "" // The compiled packages don't depend on the go-module directory
+	}
+
+	checkedGoPackages.Lock()
+	defer checkedGoPackages.Unlock()
+	if pkgs, ok := checkedGoPackages.pkgs[cacheKey]; ok {
+		obj.pkgs = pkgs
+		return nil
+	}
+	pkgs := map[string]*types.Package{}
+	if len(imports) == 0 {
+		// The go-code only uses the k8s API packages, Which are compiled into the SDK (No go-toolchain or go-module needed)
+		for importPath, pkg := range getCompiledGoPackages() {
+			pkgs[importPath] = pkg
+		}
+	} else {
+		logrus.Debug("Loading the Go-Packages for Type-Check | ", importPaths)
+		if pkgs, err = loadGoPackages(absGoModuleDir, importPaths); err != nil {
+			return err
+		}
+	}
+	for _, importPath := range sortedKeys(controllerRuntimeDeclarations) {
+		if pkgs[importPath], err = checkControllerRuntimeDeclarations(importPath, pkgs); err != nil {
+			return err
+		}
+	}
+	checkedGoPackages.pkgs[cacheKey] = pkgs
+	obj.pkgs = pkgs
+	return nil
+}
+
+/*
+Returns the type-checked package of the controller-runtime declarations (controllerRuntimeDeclarations), Its imports are resolved from the loaded packages
+*/
+func checkControllerRuntimeDeclarations(importPath string, pkgs map[string]*types.Package) (*types.Package, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, path.Base(importPath)+".go", controllerRuntimeDeclarations[importPath], parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	config := types.Config{
+		Importer: goImporterFunc(func(importPath string) (*types.Package, error) {
+			if pkg, ok := pkgs[importPath]; ok {
+				return pkg, nil
+			}
+			return nil, fmt.Errorf("go-package %s not found", importPath)
+		}),
+	}
+	pkg, err := config.Check(importPath, fileSet, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to type-check the declarations of %s| %w", importPath, err)
+	}
+	return pkg, nil
+}
+
+/*
+Returns the error-message of the error (syntax-error or type-error) at the position: The line (of the go-code) it belongs to, along with its content
+*/
+func getGoCodeErrorMessage(position token.Position, msg string, fileLines map[string][]string, startLines map[string]int) string {
+	lines, startLine := fileLines[position.Filename], startLines[position.Filename]
+	if position.Line < startLine || position.Line > len(lines) {
+		return msg
+	}
+	location := fmt.Sprintf("line %d", position.Line-startLine+1)
+	switch position.Filename {
+	case "resource.go":
+	case "helpers.go":
+		location = "type-definitions " + location
+	default: // Go-Files of the generated package (CheckFiles)
+		location = position.Filename + " " + location
+	}
+	return fmt.Sprintf("%s (%s): %s", location, strings.TrimSpace(lines[position.Line-1]), msg)
+}
+
+/*
+Input:
+
+	gocode: Go-Code of a resource (As returned by JsonStringConverter/UnstructStringConverter)
+	typeDefinitions: Go-Code of the typed go-structs of its kind (Custom-Resources whose CRD is known), "" otherwise
+
+Output: Type-Checks the go-code along with the helper fxns (int32Ptr, getDataForSecret, ...) and the type-definitions
+Returns an error containing the type-errors (Line of the go-code and the message), If the go-code wouldn't compile
+*/
+func (obj *GoCodeChecker) Check(gocode string, typeDefinitions string) error {
+	goFileObj := GoFile{Imports: obj.imports}
+	fileContents := map[string]string{
+		"helpers.go":  goFileObj.getGoFileContent(checkedGoPackageName, goFileObj.getHelperFxns()+typeDefinitions),
+		"resource.go": goFileObj.getGoFileContent(checkedGoPackageName, "\nvar _ = "+gocode+"\n"),
+	}
+	startLines := map[string]int{
+		"helpers.go":  strings.Count(fileContents["helpers.go"], "\n") - strings.Count(typeDefinitions, "\n") + 1,
+		"resource.go": strings.Count(fileContents["resource.go"], "\n") - strings.Count(gocode, "\n"),
+	}
+
+	return obj.checkFiles(fileContents, startLines)
+}
+
+/*
+Input: Generated Go-Files (File-Name as Key and its Content as Value, Same as GoFile.Files)
+Output: Type-Checks the Go-Files as one package (Example: The return-types of Get<Kind>(), getObjects and ApplyAll/CreateAll/DeleteAll),
+Returns an error containing the type-errors (File, Line and the message), If the Go-Files wouldn't compile
+*/
+func (obj *GoCodeChecker) CheckFiles(files map[string]string) error {
+	startLines := map[string]int{}
+	for fileName := range files {
+		startLines[fileName] = 1
+	}
+	return obj.checkFiles(files, startLines)
+}
+
+/*
+Type-Checks the Go-Files as one package, startLines: The line of each Go-File from which the lines are counted in the error-messages
+*/
+func (obj *GoCodeChecker) checkFiles(fileContents map[string]string, startLines map[string]int) error {
+	checkedGoPackages.Lock()
+	defer checkedGoPackages.Unlock()
+
+	fileSet := token.NewFileSet()
+	var files []*ast.File
+	fileLines := map[string][]string{}
+	for _, fileName := range sortedKeys(fileContents) {
+		fileLines[fileName] = strings.Split(fileContents[fileName], "\n")
+		file, err := parser.ParseFile(fileSet, fileName, fileContents[fileName], parser.SkipObjectResolution)
+		if err != nil {
+			var syntaxErrors scanner.ErrorList
+			if errors.As(err, &syntaxErrors) && len(syntaxErrors) != 0 {
+				return fmt.Errorf("generated go-code is not valid| %s", getGoCodeErrorMessage(syntaxErrors[0].Pos, syntaxErrors[0].Msg, fileLines, startLines))
+			}
+			return fmt.Errorf("generated go-code is not valid| %w", err)
+		}
+		files = append(files, file)
+	}
+	var typeErrors []string
+	config := types.Config{
+		Importer: goImporterFunc(func(importPath string) (*types.Package, error) {
+			if pkg, ok := obj.pkgs[importPath]; ok {
+				return pkg, nil
+			}
+			return nil, fmt.Errorf("go-package %s not found", importPath)
+		}),
+		Error: func(err error) {
+			var typeErr types.Error
+			if errors.As(err, &typeErr) {
+				typeErrors = append(typeErrors, getGoCodeErrorMessage(typeErr.Fset.Position(typeErr.Pos), typeErr.Msg, fileLines, startLines))
+			} else {
+				typeErrors = append(typeErrors, err.Error())
+			}
+		},
+	}
+	_, _ = config.Check(checkedGoPackageName, fileSet, files, nil)
+	if len(typeErrors) == 0 {
+		return nil
+	}
+	logrus.Debug("Type-Errors in the Go-Code | ", typeErrors)
+	if len(typeErrors) > maxReportedTypeErrors {
+		typeErrors = append(typeErrors[:maxReportedTypeErrors], fmt.Sprintf("and %d more errors", len(typeErrors)-maxReportedTypeErrors))
+	}
+	return fmt.Errorf("generated go-code doesn't compile| %s", strings.Join(typeErrors, "; "))
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoCodeChecker(t *testing.T) {
+	var goCodeCheckerObj = GoCodeChecker{}
+	if err := goCodeCheckerObj.Intialise("", nil); err != nil {
+		t.Fatalf("Unable to Intialise the GoCodeChecker | Error %v", err)
+	}
+
+	tests := []Tests{
+		{
+			input:    "&appsv1.Deployment{\n\tSpec: appsv1.DeploymentSpec{\n\t\tReplicas: int32Ptr(3),\n\t},\n}",
+			expected: "",
+		},
+		{
+			input:    "&corev1.Secret{\n\tData: map[string][]byte{\n\t\t\"key\": getDataForSecret(\"dmFsdWU=\"),\n\t},\n}",
+			expected: "",
+		},
+		{
+			input:    "&appsv1.Deployment{\n\tSpec: appsv1.DeploymentSpec{\n\t\tReplicas: 3,\n\t},\n}",
+			expected: "line 3 (Replicas: 3,): cannot use 3",
+		},
+		{
+			input:    "&appsv1.Deployment{\n\tSpec: appsv1.DeploymentSpec{\n\t\tUnknown: \"abc\",\n\t},\n}",
+			expected: "line 3 (Unknown: \"abc\",): unknown field Unknown",
+		},
+		{
+			input:    "&nadv1.NetworkAttachmentDefinition{}",
+			expected: "undefined: nadv1",
+		},
+	}
+	for _, test := range tests {
+		err := goCodeCheckerObj.Check(test.input.(string), "")
+		if test.expected.(string) == "" {
+			if err != nil {
+				t.Errorf("Type-Check Failed | Expected no error | Got %v | Input %s", err, test.input)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.expected.(string)) {
+			t.Errorf("Type-Check Failed | Expected error containing '%s' | Got %v | Input %s", test.expected, err, test.input)
+		}
+	}
+
+	// Go-Code of the Custom-Resources is Type-Checked along with the Type-Definitions of its Kind
+	typeDefinitions := "\ntype Database struct {\n\tmetav1.TypeMeta `json:\",inline\"`\n\tSize UnknownType\n}\n"
+	if err := goCodeCheckerObj.Check("&Database{}", typeDefinitions); err == nil || !strings.Contains(err.Error(), "type-definitions line 4 (Size UnknownType): undefined: UnknownType") {
+		t.Errorf("Expected error for the Invalid Type-Definitions, Got %v", err)
+	}
+}
+
+func TestGoCodeCheckerCheckFiles(t *testing.T) {
+	var goCodeCheckerObj = GoCodeChecker{}
+	if err := goCodeCheckerObj.Intialise("", nil); err != nil {
+		t.Fatalf("Unable to Intialise the GoCodeChecker | Error %v", err)
+	}
+	validFile := "package main\n\nimport (\n\tcorev1 \"k8s.io/api/core/v1\"\n\t\"sigs.k8s.io/controller-runtime/pkg/client\"\n)\n\n" +
+		"func GetService() []*corev1.Service {\n\treturn []*corev1.Service{{}}\n}\n\n" +
+		"func getObjects() []client.Object {\n\tvar objects []client.Object\n\tfor _, obj := range GetService() {\n\t\tobjects = append(objects, obj)\n\t}\n\treturn objects\n}\n"
+	invalidFile := "package main\n\nimport (\n\tcorev1 \"k8s.io/api/core/v1\"\n)\n\n" +
+		"func GetService() []*corev1.Service {\n\treturn []*corev1.Secret{{}}\n}\n"
+	tests := []Tests{
+		{
+			input:    map[string]string{"generated_code.go": validFile},
+			expected: "",
+		},
+		{
+			input:    map[string]string{"generated_code.go": invalidFile},
+			expected: "generated_code.go line 8",
+		},
+	}
+	for _, test := range tests {
+		err := goCodeCheckerObj.CheckFiles(test.input.(map[string]string))
+		if test.expected.(string) == "" {
+			if err != nil {
+				t.Errorf("Type-Check Failed | Expected no error | Got %v", err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.expected.(string)) {
+			t.Errorf("Type-Check Failed | Expected error containing '%s' | Got %v", test.expected, err)
+		}
+	}
+}
+
+func TestGoCodeCheckerOutsideGoModule(t *testing.T) {
+	// The k8s API packages are compiled into the SDK, Therefore the go-code is type-checked outside a go-module as well
+	var goCodeCheckerObj = GoCodeChecker{}
+	if err := goCodeCheckerObj.Intialise(t.TempDir(), nil); err != nil {
+		t.Fatalf("Unable to Intialise the GoCodeChecker outside a go-module | Error %v", err)
+	}
+	tests := []Tests{
+		{
+			input: "&corev1.Pod{\n\tObjectMeta: metav1.ObjectMeta{\n\t\tCreationTimestamp: metav1.Time{Time: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},\n\t},\n" +
+				"\tSpec: corev1.PodSpec{\n\t\tActiveDeadlineSeconds: ptr.To[int64](5),\n\t\tContainers: []corev1.Container{\n\t\t\t{\n" +
+				"\t\t\t\tResources: corev1.ResourceRequirements{\n\t\t\t\t\tLimits: corev1.ResourceList{\n\t\t\t\t\t\t\"cpu\": resource.MustParse(\"1\"),\n\t\t\t\t\t},\n\t\t\t\t},\n" +
+				"\t\t\t\tLivenessProbe: &corev1.Probe{\n\t\t\t\t\tProbeHandler: corev1.ProbeHandler{\n\t\t\t\t\t\tHTTPGet: &corev1.HTTPGetAction{\n" +
+				"\t\t\t\t\t\t\tPort: intstr.IntOrString{Type: intstr.Int, IntVal: 80},\n\t\t\t\t\t\t},\n\t\t\t\t\t},\n\t\t\t\t},\n\t\t\t},\n\t\t},\n\t},\n}",
+			expected: "",
+		},
+		{
+			input:    "&corev1.Pod{\n\tSpec: corev1.PodSpec{\n\t\tActiveDeadlineSeconds: ptr.To[int32](5),\n\t},\n}",
+			expected: "line 3 (ActiveDeadlineSeconds: ptr.To[int32](5),): cannot use ptr.To[int32](5)",
+		},
+	}
+	for _, test := range tests {
+		err := goCodeCheckerObj.Check(test.input.(string), "")
+		if test.expected.(string) == "" {
+			if err != nil {
+				t.Errorf("Type-Check Failed | Expected no error | Got %v | Input %s", err, test.input)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.expected.(string)) {
+			t.Errorf("Type-Check Failed | Expected error containing '%s' | Got %v | Input %s", test.expected, err, test.input)
+		}
+	}
+
+	// The Go-Packages of the Go-Types are loaded from the go-module, Therefore they can't be resolved outside it
+	if err := goCodeCheckerObj.Intialise(t.TempDir(), map[string]string{"examplev1beta1": "example.com/apis/example/v1beta1"}); err == nil {
+		t.Errorf("Expected error while Intialising the GoCodeChecker with Go-Types outside a go-module")
+	}
+}
@@ -0,0 +1,734 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+// Value reported for the fields which are present only on one side of the round-trip
+const missingFieldValue = "<missing>"
+
+// Predeclared types which can be used by the generated go-code
+var predeclaredGoTypes = map[string]reflect.Type{
+	"string": reflect.TypeOf(""), "bool": reflect.TypeOf(false), "byte": reflect.TypeOf(byte(0)), "any": reflect.TypeOf((*any)(nil)).Elem(),
+	"int": reflect.TypeOf(int(0)), "int8": reflect.TypeOf(int8(0)), "int16": reflect.TypeOf(int16(0)), "int32": reflect.TypeOf(int32(0)), "int64": reflect.TypeOf(int64(0)),
+	"uint": reflect.TypeOf(uint(0)), "uint8": reflect.TypeOf(uint8(0)), "uint16": reflect.TypeOf(uint16(0)), "uint32": reflect.TypeOf(uint32(0)), "uint64": reflect.TypeOf(uint64(0)),
+	"float32": reflect.TypeOf(float32(0)), "float64": reflect.TypeOf(float64(0)),
+}
+
+// Pointer helper fxns of the generated go-code (getHelperFxns) as Key and the data-type they point to as Value
+var pointerHelperFxns = map[string]reflect.Type{
+	"int32Ptr": predeclaredGoTypes["int32"], "int64Ptr": predeclaredGoTypes["int64"], "intPtr": predeclaredGoTypes["int"],
+	"int16Ptr": predeclaredGoTypes["int16"], "boolPtr": predeclaredGoTypes["bool"], "stringPtr": predeclaredGoTypes["string"],
+}
+
+/*
+Evaluates the generated go-code of a resource (Without compiling it), And compares the resulting object with the decoded input-resource,
+So that the fields which are changed or silently dropped during the conversion are reported (Round-Trip Verification)
+The data-types known at runtime are evaluated: The kinds registered in the client-go scheme and unstructured.Unstructured,
+The typed go-structs of the CRDs & the Go-Types of --go-types are evaluated using their data-types built at runtime (See WithGoTypes)
+*/
+type GoCodeEvaluator struct {
+	goTypes      map[string]reflect.Type // Named data-types (As written in the go-code, Example: appsv1.DeploymentSpec) as Key, To be set by Intialise
+	localGoTypes map[string]reflect.Type // Data-Types of the typed go-structs (As written in the go-code, Example: DatabaseSpec) as Key, Set by WithGoTypes
+}
+
+/*
+Error of the evaluation, Along with the path of the field (Example: .Spec.Replicas) whose go-code couldn't be evaluated
+*/
+type goCodeEvalError struct {
+	path string
+	err  error
+}
+
+func (e *goCodeEvalError) Error() string {
+	return strings.TrimPrefix(e.path, ".") + ": " + e.err.Error()
+}
+
+/*
+Prefixes the path of the error with the path-segment of the field (.Spec), slice-element ([0]) or map-value (["key"])
+*/
+func wrapEvalError(segment string, err error) error {
+	if evalErr, ok := err.(*goCodeEvalError); ok {
+		return &goCodeEvalError{path: segment + evalErr.path, err: evalErr.err}
+	}
+	return &goCodeEvalError{path: segment, err: err}
+}
+
+/*
+Recursive Function (DFS Algorithm) to traverse the data-type (and the data-types of its fields/elements) and collect the named data-types
+*/
+func collectGoTypes(t reflect.Type, goTypes map[string]reflect.Type) {
+	if t.Name() != "" && t.PkgPath() != "" {
+		if _, ok := goTypes[getGoTypeName(t)]; ok {
+			return
+		}
+		goTypes[getGoTypeName(t)] = t
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		collectGoTypes(t.Elem(), goTypes)
+	case reflect.Map:
+		collectGoTypes(t.Key(), goTypes)
+		collectGoTypes(t.Elem(), goTypes)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			collectGoTypes(t.Field(i).Type, goTypes)
+		}
+	}
+}
+
+/*
+Intialises the GoCodeEvaluator with the data-types of all the kinds registered in the client-go scheme
+*/
+func (obj *GoCodeEvaluator) Intialise() {
+	obj.goTypes = map[string]reflect.Type{}
+	for _, objType := range scheme.Scheme.AllKnownTypes() {
+		collectGoTypes(objType, obj.goTypes)
+	}
+	collectGoTypes(reflect.TypeOf(unstructured.Unstructured{}), obj.goTypes)
+	collectGoTypes(reflect.TypeOf(json.RawMessage{}), obj.goTypes) // Used by the Go-Types
+}
+
+/*
+Returns a GoCodeEvaluator which evaluates the typed go-structs as well (Go-Type-Name as written in the go-code as Key and its data-type as Value),
+Example: The go-structs of a CRD (See CrdStructConverter.GetGoTypes) or the Go-Types of --go-types (See GoTypeConverter.GetGoTypes)
+*/
+func (obj *GoCodeEvaluator) WithGoTypes(goTypes map[string]reflect.Type) *GoCodeEvaluator {
+	return &GoCodeEvaluator{goTypes: obj.goTypes, localGoTypes: goTypes}
+}
+
+/*
+Returns the data-type represented by the type-expression of the go-code (Example: []corev1.Container), nil if it is not a known data-type
+*/
+func (obj *GoCodeEvaluator) getType(expr ast.Expr) reflect.Type {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if localType, ok := obj.localGoTypes[e.Name]; ok {
+			return localType
+		}
+		return predeclaredGoTypes[e.Name]
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok {
+			if localType, ok := obj.localGoTypes[pkg.Name+"."+e.Sel.Name]; ok {
+				return localType
+			}
+			return obj.goTypes[pkg.Name+"."+e.Sel.Name]
+		}
+	case *ast.StarExpr:
+		if elemType := obj.getType(e.X); elemType != nil {
+			return reflect.PointerTo(elemType)
+		}
+	case *ast.ArrayType:
+		if elemType := obj.getType(e.Elt); elemType != nil && e.Len == nil {
+			return reflect.SliceOf(elemType)
+		}
+	case *ast.MapType:
+		keyType, valueType := obj.getType(e.Key), obj.getType(e.Value)
+		if keyType != nil && valueType != nil {
+			return reflect.MapOf(keyType, valueType)
+		}
+	case *ast.InterfaceType:
+		if len(e.Methods.List) == 0 {
+			return predeclaredGoTypes["any"]
+		}
+	case *ast.ParenExpr:
+		return obj.getType(e.X)
+	}
+	return nil
+}
+
+/*
+Returns the value of the constant-expression (Basic-Literals, true/false, -5, "line-1\n" + "line-2"), ok is false if it is not a constant
+*/
+func getConstantValue(expr ast.Expr) (constant.Value, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		value := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		return value, value.Kind() != constant.Unknown
+	case *ast.Ident:
+		if e.Name == "true" || e.Name == "false" {
+			return constant.MakeBool(e.Name == "true"), true
+		}
+	case *ast.ParenExpr:
+		return getConstantValue(e.X)
+	case *ast.UnaryExpr:
+		if value, ok := getConstantValue(e.X); ok && (e.Op == token.SUB || e.Op == token.ADD) {
+			return constant.UnaryOp(e.Op, value, 0), true
+		}
+	case *ast.BinaryExpr:
+		x, xOk := getConstantValue(e.X)
+		y, yOk := getConstantValue(e.Y)
+		if xOk && yOk && e.Op == token.ADD && x.Kind() == y.Kind() {
+			return constant.BinaryOp(x, e.Op, y), true
+		}
+	}
+	return nil, false
+}
+
+/*
+Returns the constant-value as the data-type t, If t is nil or an interface (unstructured.Unstructured), The default data-type of the constant is used
+*/
+func getConstantAs(value constant.Value, t reflect.Type) (reflect.Value, error) {
+	if t == nil || t.Kind() == reflect.Interface {
+		switch value.Kind() {
+		case constant.String:
+			return getConstantAs(value, predeclaredGoTypes["string"])
+		case constant.Bool:
+			return getConstantAs(value, predeclaredGoTypes["bool"])
+		case constant.Int:
+			return getConstantAs(value, predeclaredGoTypes["int64"]) // Same as the integers of the decoded unstructured-objects
+		default:
+			return getConstantAs(value, predeclaredGoTypes["float64"])
+		}
+	}
+	v := reflect.New(t).Elem()
+	switch {
+	case t.Kind() == reflect.String && value.Kind() == constant.String:
+		v.SetString(constant.StringVal(value))
+	case t.Kind() == reflect.Bool && value.Kind() == constant.Bool:
+		v.SetBool(constant.BoolVal(value))
+	case v.CanInt() && value.Kind() == constant.Int:
+		intVal, exact := constant.Int64Val(value)
+		if !exact || v.OverflowInt(intVal) {
+			return v, fmt.Errorf("constant %s overflows %s", value, getGoTypeName(t))
+		}
+		v.SetInt(intVal)
+	case v.CanUint() && value.Kind() == constant.Int:
+		uintVal, exact := constant.Uint64Val(value)
+		if !exact || v.OverflowUint(uintVal) {
+			return v, fmt.Errorf("constant %s overflows %s", value, getGoTypeName(t))
+		}
+		v.SetUint(uintVal)
+	case v.CanFloat() && (value.Kind() == constant.Int || value.Kind() == constant.Float):
+		floatVal, _ := constant.Float64Val(value)
+		v.SetFloat(floatVal)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && value.Kind() == constant.String:
+		v.SetBytes([]byte(constant.StringVal(value))) // []byte("...")
+	default:
+		return v, fmt.Errorf("cannot use constant %s as %s", value, getGoTypeName(t))
+	}
+	return v, nil
+}
+
+/*
+Returns the value v as the data-type t (Converting it if needed), v is returned as it is if t is nil
+*/
+func assignValue(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	switch {
+	case t == nil || v.Type() == t:
+		return v, nil
+	case t.Kind() == reflect.Interface && v.Type().Implements(t):
+		out := reflect.New(t).Elem()
+		out.Set(v)
+		return out, nil
+	case v.Type().ConvertibleTo(t) && v.Kind() == t.Kind():
+		return v.Convert(t), nil
+	}
+	return v, fmt.Errorf("cannot use %s as %s", getGoTypeName(v.Type()), getGoTypeName(t))
+}
+
+/*
+Returns the value of the composite-literal (Struct, Slice or Map), t is the expected data-type (Used if the composite-literal doesn't have its type)
+*/
+func (obj *GoCodeEvaluator) evalCompositeLit(lit *ast.CompositeLit, t reflect.Type) (reflect.Value, error) {
+	litType := t
+	if lit.Type != nil {
+		if litType = obj.getType(lit.Type); litType == nil {
+			return reflect.Value{}, fmt.Errorf("unknown data-type %s", exprString(lit.Type))
+		}
+	} else if litType == nil {
+		return reflect.Value{}, fmt.Errorf("composite-literal without data-type")
+	} else if litType.Kind() == reflect.Ptr {
+		// Elided &T{} in the elements of a slice/map
+		v, err := obj.evalCompositeLit(lit, litType.Elem())
+		if err != nil {
+			return v, err
+		}
+		return addressOf(v), nil
+	}
+
+	v := reflect.New(litType).Elem()
+	switch litType.Kind() {
+	case reflect.Struct:
+		for _, elt := range lit.Elts {
+			keyValue, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				return v, fmt.Errorf("unkeyed fields of %s are not supported", getGoTypeName(litType))
+			}
+			fieldName, ok := keyValue.Key.(*ast.Ident)
+			if !ok {
+				return v, fmt.Errorf("invalid field %s of %s", exprString(keyValue.Key), getGoTypeName(litType))
+			}
+			field, ok := litType.FieldByName(fieldName.Name)
+			if !ok || len(field.Index) != 1 || !field.IsExported() {
+				return v, fmt.Errorf("unknown field %s of %s", fieldName.Name, getGoTypeName(litType))
+			}
+			fieldVal, err := obj.evalExpr(keyValue.Value, field.Type)
+			if err != nil {
+				return v, wrapEvalError("."+fieldName.Name, err)
+			}
+			v.Field(field.Index[0]).Set(fieldVal)
+		}
+	case reflect.Slice:
+		v = reflect.MakeSlice(litType, 0, len(lit.Elts))
+		for i, elt := range lit.Elts {
+			eltVal, err := obj.evalExpr(elt, litType.Elem())
+			if err != nil {
+				return v, wrapEvalError(fmt.Sprintf("[%d]", i), err)
+			}
+			v = reflect.Append(v, eltVal)
+		}
+	case reflect.Map:
+		v = reflect.MakeMapWithSize(litType, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			keyValue, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				return v, fmt.Errorf("map-element without key")
+			}
+			keyVal, err := obj.evalExpr(keyValue.Key, litType.Key())
+			if err != nil {
+				return v, err
+			}
+			eltVal, err := obj.evalExpr(keyValue.Value, litType.Elem())
+			if err != nil {
+				return v, wrapEvalError(fmt.Sprintf("[%q]", fmt.Sprint(keyVal)), err)
+			}
+			v.SetMapIndex(keyVal, eltVal)
+		}
+	default:
+		return v, fmt.Errorf("composite-literal of %s is not supported", getGoTypeName(litType))
+	}
+	return v, nil
+}
+
+/*
+Returns the value of the fxn-call (Helper fxns, resource.MustParse, time.Date, ptr.To) or the type-conversion (Enums, Example: corev1.Protocol("TCP"))
+*/
+func (obj *GoCodeEvaluator) evalCallExpr(call *ast.CallExpr, t reflect.Type) (reflect.Value, error) {
+	if convType := obj.getType(call.Fun); convType != nil {
+		if len(call.Args) != 1 {
+			return reflect.Value{}, fmt.Errorf("invalid conversion to %s", getGoTypeName(convType))
+		}
+		if value, ok := getConstantValue(call.Args[0]); ok {
+			return getConstantAs(value, convType)
+		}
+		argVal, err := obj.evalExpr(call.Args[0], nil)
+		if err != nil || !argVal.Type().ConvertibleTo(convType) {
+			return reflect.Value{}, fmt.Errorf("invalid conversion to %s", getGoTypeName(convType))
+		}
+		return argVal.Convert(convType), nil
+	}
+
+	fxnName := exprString(call.Fun)
+	if elemType, ok := pointerHelperFxns[fxnName]; ok && len(call.Args) == 1 {
+		argVal, err := obj.evalExpr(call.Args[0], elemType)
+		if err != nil {
+			return argVal, err
+		}
+		return addressOf(argVal), nil
+	}
+	if indexExpr, ok := call.Fun.(*ast.IndexExpr); ok && exprString(indexExpr.X) == "ptr.To" && len(call.Args) == 1 {
+		elemType := obj.getType(indexExpr.Index)
+		if elemType == nil {
+			return reflect.Value{}, fmt.Errorf("unknown data-type %s", exprString(indexExpr.Index))
+		}
+		argVal, err := obj.evalExpr(call.Args[0], elemType)
+		if err != nil {
+			return argVal, err
+		}
+		return addressOf(argVal), nil
+	}
+
+	switch {
+	case fxnName == "ptr.To" && len(call.Args) == 1:
+		var elemType reflect.Type
+		if t != nil && t.Kind() == reflect.Ptr {
+			elemType = t.Elem()
+		}
+		argVal, err := obj.evalExpr(call.Args[0], elemType)
+		if err != nil {
+			return argVal, err
+		}
+		return addressOf(argVal), nil
+	case fxnName == "getDataForSecret" && len(call.Args) == 1:
+		encodedVal, err := obj.evalExpr(call.Args[0], predeclaredGoTypes["string"])
+		if err != nil {
+			return encodedVal, err
+		}
+		decodedVal, err := base64.StdEncoding.DecodeString(encodedVal.String())
+		if err != nil {
+			// getDataForSecret panics on an invalid value
+			return reflect.Value{}, fmt.Errorf("unable to decode the secret-data %q| %w", encodedVal.String(), err)
+		}
+		return reflect.ValueOf(decodedVal), nil
+	case fxnName == "resource.MustParse" && len(call.Args) == 1:
+		quantityVal, err := obj.evalExpr(call.Args[0], predeclaredGoTypes["string"])
+		if err != nil {
+			return quantityVal, err
+		}
+		quantity, err := resource.ParseQuantity(quantityVal.String())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("resource.MustParse(%q) would panic| %w", quantityVal.String(), err)
+		}
+		return reflect.ValueOf(quantity), nil
+	case fxnName == "time.Date" && len(call.Args) == 8:
+		return evalTimeDate(call.Args)
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported fxn-call %s", exprString(call.Fun))
+}
+
+/*
+Returns the value of time.Date(year, time.Month, day, hour, min, sec, nsec, time.UTC/time.Local), As written by time.Time.GoString()
+*/
+func evalTimeDate(args []ast.Expr) (reflect.Value, error) {
+	var intArgs []int
+	var month time.Month
+	for i, arg := range args[:7] {
+		if i == 1 {
+			monthName := strings.TrimPrefix(exprString(arg), "time.")
+			for m := time.January; m <= time.December; m++ {
+				if m.String() == monthName {
+					month = m
+				}
+			}
+			if month == 0 {
+				return reflect.Value{}, fmt.Errorf("invalid month %s", exprString(arg))
+			}
+			continue
+		}
+		value, ok := getConstantValue(arg)
+		intVal, exact := int64(0), false
+		if ok && value.Kind() == constant.Int {
+			intVal, exact = constant.Int64Val(value)
+		}
+		if !exact {
+			return reflect.Value{}, fmt.Errorf("invalid argument %s of time.Date", exprString(arg))
+		}
+		intArgs = append(intArgs, int(intVal))
+	}
+	var location *time.Location
+	switch exprString(args[7]) {
+	case "time.UTC":
+		location = time.UTC
+	case "time.Local":
+		location = time.Local
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported location %s of time.Date", exprString(args[7]))
+	}
+	return reflect.ValueOf(time.Date(intArgs[0], month, intArgs[1], intArgs[2], intArgs[3], intArgs[4], intArgs[5], location)), nil
+}
+
+/*
+Returns the value of the go-expression as the data-type t (nil, if the data-type is to be derived from the expression itself)
+*/
+func (obj *GoCodeEvaluator) evalExpr(expr ast.Expr, t reflect.Type) (reflect.Value, error) {
+	if value, ok := getConstantValue(expr); ok {
+		return getConstantAs(value, t)
+	}
+	var v reflect.Value
+	var err error
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return obj.evalExpr(e.X, t)
+	case *ast.Ident:
+		if e.Name == "nil" && t != nil {
+			return reflect.Zero(t), nil
+		}
+		return v, fmt.Errorf("undefined: %s", e.Name)
+	case *ast.UnaryExpr:
+		if e.Op != token.AND {
+			return v, fmt.Errorf("unsupported operator %s", e.Op)
+		}
+		var elemType reflect.Type
+		if t != nil && t.Kind() == reflect.Ptr {
+			elemType = t.Elem()
+		}
+		if v, err = obj.evalExpr(e.X, elemType); err != nil {
+			return v, err
+		}
+		v = addressOf(v)
+	case *ast.CompositeLit:
+		v, err = obj.evalCompositeLit(e, t)
+	case *ast.CallExpr:
+		v, err = obj.evalCallExpr(e, t)
+	default:
+		return v, fmt.Errorf("unsupported go-expression %s", exprString(expr))
+	}
+	if err != nil {
+		return v, err
+	}
+	return assignValue(v, t)
+}
+
+/*
+Returns the pointer to a copy of v
+*/
+func addressOf(v reflect.Value) reflect.Value {
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr
+}
+
+/*
+Returns the go-code of the expression (Used in the error-messages and to match the fxn-names)
+*/
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.IndexExpr:
+		return exprString(e.X) + "[" + exprString(e.Index) + "]"
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(e.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(e.Key) + "]" + exprString(e.Value)
+	case *ast.BasicLit:
+		return e.Value
+	}
+	return fmt.Sprintf("%T", expr)
+}
+
+/*
+Evaluates the go-code of a resource (As returned by JsonStringConverter/UnstructStringConverter) and returns the resulting object
+Example: "&corev1.Service{...}" returns *corev1.Service
+*/
+func (obj *GoCodeEvaluator) Evaluate(gocode string) (any, error) {
+	expr, err := parser.ParseExpr(gocode)
+	if err != nil {
+		return nil, fmt.Errorf("generated go-code is not valid| %w", err)
+	}
+	v, err := obj.evalExpr(expr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate the generated go-code| %w", err)
+	}
+	return v.Interface(), nil
+}
+
+/*
+Returns the object as generic json-values (map[string]any, []any, string, float64, bool), So that the objects are compared semantically
+(Example: resource.Quantity 1Gi and 1024Mi are the same, The integers of unstructured-objects (int64) and of go-structs (int32) are the same)
+*/
+func getJsonValue(object any) (any, error) {
+	if unstructObj, ok := object.(*unstructured.Unstructured); ok {
+		object = unstructObj.Object
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var jsonValue any
+	err = json.Unmarshal(data, &jsonValue)
+	return jsonValue, err
+}
+
+/*
+Recursive Function (DFS Algorithm) to remove the Helm-Specific-Labels from the "labels" of the json-value (metadata.labels, spec.template.metadata.labels, ...),
+Same as the RuntimeJsonConverter (See refactorHelmLabels), The "labels" having only the Helm-Specific-Labels are removed as well
+*/
+func removeHelmLabels(jsonValue any) {
+	switch value := jsonValue.(type) {
+	case map[string]any:
+		for key, val := range value {
+			if labels, isMap := val.(map[string]any); isMap && key == "labels" {
+				for _, helmLabel := range helmSpecificLabels {
+					delete(labels, helmLabel)
+				}
+				if len(labels) == 0 {
+					delete(value, key)
+				}
+				continue
+			}
+			removeHelmLabels(val)
+		}
+	case []any:
+		for _, val := range value {
+			removeHelmLabels(val)
+		}
+	}
+}
+
+/*
+Returns the unstructured-object decoded into the data-type of the typed go-struct (Pointer to the struct), So that the fields of the typed go-struct
+which are never omitted (Example: Non-pointer structs, status: {}) are in the expected object as well, Same as of the runtime-objects
+The unstructured-object is returned as it is, If it can't be decoded without losing any field (Example: The unknown-fields of the CRDs, See crdUnknownFieldsJsonKey)
+*/
+func decodeAsTypedObject(unstructObj *unstructured.Unstructured, t reflect.Type) any {
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return unstructObj
+	}
+	data, err := json.Marshal(unstructObj.Object)
+	if err != nil {
+		return unstructObj
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	typedObj := reflect.New(t.Elem())
+	if err := decoder.Decode(typedObj.Interface()); err != nil {
+		return unstructObj
+	}
+	return typedObj.Interface()
+}
+
+/*
+Recursive Function (DFS Algorithm) to normalise the json-value of an object: The null-values of the maps are removed (Same as not set, Example: metadata.creationTimestamp),
+And the keys of the unknown-fields maps of the typed go-structs of the CRDs (crdUnknownFieldsJsonKey) are moved to the map they belong to
+*/
+func normaliseJsonValue(jsonValue any) {
+	switch value := jsonValue.(type) {
+	case map[string]any:
+		if unknownFields, isMap := value[crdUnknownFieldsJsonKey].(map[string]any); isMap {
+			delete(value, crdUnknownFieldsJsonKey)
+			for key, val := range unknownFields {
+				value[key] = val
+			}
+		}
+		for key, val := range value {
+			if val == nil {
+				delete(value, key)
+				continue
+			}
+			normaliseJsonValue(val)
+		}
+	case []any:
+		for _, val := range value {
+			normaliseJsonValue(val)
+		}
+	}
+}
+
+/*
+Returns the json-value as written in the differences (Compact json)
+*/
+func formatJsonValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+/*
+Recursive Function (DFS Algorithm) to compare the json-values, And collect the differences: "<field-path>: expected <value>, got <value>"
+*/
+func collectJsonDiffs(path string, expected any, actual any, diffs *[]string) {
+	expectedMap, expectedIsMap := expected.(map[string]any)
+	actualMap, actualIsMap := actual.(map[string]any)
+	if expectedIsMap && actualIsMap {
+		keys := map[string]bool{}
+		for key := range expectedMap {
+			keys[key] = true
+		}
+		for key := range actualMap {
+			keys[key] = true
+		}
+		for _, key := range sortedKeys(keys) {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			expectedVal, expectedOk := expectedMap[key]
+			actualVal, actualOk := actualMap[key]
+			switch {
+			case !actualOk:
+				*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", fieldPath, formatJsonValue(expectedVal), missingFieldValue))
+			case !expectedOk:
+				*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", fieldPath, missingFieldValue, formatJsonValue(actualVal)))
+			default:
+				collectJsonDiffs(fieldPath, expectedVal, actualVal, diffs)
+			}
+		}
+		return
+	}
+	expectedSlice, expectedIsSlice := expected.([]any)
+	actualSlice, actualIsSlice := actual.([]any)
+	if expectedIsSlice && actualIsSlice && len(expectedSlice) == len(actualSlice) {
+		for i := range expectedSlice {
+			collectJsonDiffs(fmt.Sprintf("%s[%d]", path, i), expectedSlice[i], actualSlice[i], diffs)
+		}
+		return
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", path, formatJsonValue(expected), formatJsonValue(actual)))
+	}
+}
+
+/*
+Input:
+
+	gocode: Go-Code of a resource (As returned by JsonStringConverter/UnstructStringConverter)
+	expected: The decoded input-resource (runtime-object or *unstructured.Unstructured) the go-code is generated from
+
+Output: Evaluates the go-code and compares the resulting object with the expected one semantically (The null-values are same as not set)
+The unstructured input of the typed go-structs (CRDs & Go-Types) is compared as the typed go-struct, Same as the runtime-objects (See decodeAsTypedObject)
+Returns the differences (In the order of the field-paths, Example: "spec.replicas: expected 0, got <missing>"), Empty if the objects are equal
+Along with the Helm-Specific-Labels of the typed go-structs, Which are dropped on purpose by the RuntimeJsonConverter (Reported separately, Same format as the differences)
+Returns an error, If the go-code can't be evaluated
+*/
+func (obj *GoCodeEvaluator) Verify(gocode string, expected any) ([]string, []string, error) {
+	actual, err := obj.Evaluate(gocode)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, isUnstructActual := actual.(*unstructured.Unstructured)
+	if unstructObj, isUnstruct := expected.(*unstructured.Unstructured); isUnstruct && !isUnstructActual {
+		expected = decodeAsTypedObject(unstructObj, reflect.TypeOf(actual))
+	}
+	expectedJson, err := getJsonValue(expected)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal the input-resource| %w", err)
+	}
+	var dropped []string
+	if !isUnstructActual {
+		// The Helm-Specific-Labels of the typed go-structs are not written to the go-code (The UnstructStringConverter keeps them)
+		withoutHelmLabels, err := getJsonValue(expected)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to marshal the input-resource| %w", err)
+		}
+		removeHelmLabels(withoutHelmLabels)
+		collectJsonDiffs("", expectedJson, withoutHelmLabels, &dropped)
+		expectedJson = withoutHelmLabels
+	}
+	actualJson, err := getJsonValue(actual)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal the evaluated go-code| %w", err)
+	}
+	normaliseJsonValue(expectedJson)
+	normaliseJsonValue(actualJson)
+	var diffs []string
+	collectJsonDiffs("", expectedJson, actualJson, &diffs)
+	return diffs, dropped, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGoCodeEvaluator(t *testing.T) {
+	var goCodeEvaluatorObj = GoCodeEvaluator{}
+	goCodeEvaluatorObj.Intialise()
+
+	replicas := int32(0)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "zero", Labels: map[string]string{"helm.sh/chart": "chart-0.1.0"}},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas, Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "nginx", Command: []string{"sh", "-c", "echo \"hi\"\nls"},
+			Ports:     []corev1.ContainerPort{{ContainerPort: 80, Protocol: corev1.ProtocolTCP}},
+			Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}},
+		}}}}},
+	}
+	tests := []Tests{
+		{
+			// The Helm-Specific-Labels are removed by the RuntimeJsonConverter on purpose, Therefore they are reported as dropped (Not as differences)
+			input: "&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: \"zero\"}, " +
+				"Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(0), Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{corev1.Container{" +
+				"Name: \"nginx\", Command: []string{\"sh\", \"-c\", \"echo \\\"hi\\\"\\n\" + \n \"ls\"}, Ports: []corev1.ContainerPort{corev1.ContainerPort{ContainerPort: 80, Protocol: corev1.Protocol(\"TCP\")}}, " +
+				"Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{\"cpu\": resource.MustParse(\"0.5\")}}}}}}}}",
+			expected: "",
+		},
+		{
+			input:    "&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: \"zero\"}, Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{corev1.Container{Name: \"nginx\", Command: []string{\"sh\", \"-c\", \"echo \\\"hi\\\"\\nls\"}, Ports: []corev1.ContainerPort{corev1.ContainerPort{ContainerPort: 80, Protocol: corev1.Protocol(\"TCP\")}}, Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{\"cpu\": resource.MustParse(\"500m\")}}}}}}}}",
+			expected: "spec.replicas: expected 0, got <missing>",
+		},
+		{
+			input:    "&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(\"three\")}}",
+			expected: "unable to evaluate the generated go-code| Spec.Replicas: cannot use constant \"three\" as int32",
+		},
+		{
+			input:    "&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Unknown: 3}}",
+			expected: "unable to evaluate the generated go-code| Spec: unknown field Unknown of appsv1.DeploymentSpec",
+		},
+	}
+	for _, test := range tests {
+		diffs, _, err := goCodeEvaluatorObj.Verify(test.input.(string), deployment)
+		result := strings.Join(diffs, "; ")
+		if err != nil {
+			result = err.Error()
+		}
+		if result != test.expected.(string) {
+			t.Errorf("Round-Trip Verification Failed | Input %s | Expected %s | Got %s", test.input, test.expected, result)
+		}
+	}
+
+	_, dropped, err := goCodeEvaluatorObj.Verify(tests[0].input.(string), deployment)
+	if err != nil || strings.Join(dropped, "; ") != `metadata.labels: expected {"helm.sh/chart":"chart-0.1.0"}, got <missing>` {
+		t.Errorf("Dropped Helm-Specific-Labels are not as expected | Got %v %v", dropped, err)
+	}
+
+	// Unstructured-Objects are compared semantically (The integers of the go-code and of the decoded object are the same)
+	unstructObj := &unstructured.Unstructured{Object: map[string]any{"kind": "Thing", "spec": map[string]any{"count": int64(0), "enabled": false, "ratio": 1.5, "list": []any{"a", int64(1), true}}}}
+	gocode := "&unstructured.Unstructured{Object: map[string]any{\"kind\": \"Thing\", \"spec\": map[string]any{\"count\": 0, \"enabled\": false, \"ratio\": 1.5, \"list\": []any{\"a\", 1, false}}}}"
+	diffs, dropped, err := goCodeEvaluatorObj.Verify(gocode, unstructObj)
+	if err != nil || strings.Join(diffs, "; ") != "spec.list[2]: expected true, got false" || len(dropped) != 0 {
+		t.Errorf("Round-Trip Verification of Unstructured Failed | Got %v %v", diffs, err)
+	}
+
+	// Secret-Data is decoded by getDataForSecret & metav1.Time is written using time.Date
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Date(2023, 3, 3, 0, 0, 0, 0, time.UTC)}, Data: map[string][]byte{"key": []byte("value")}}
+	gocode = "&corev1.Secret{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC)}}, Data: map[string][]byte{\"key\": getDataForSecret(\"dmFsdWU=\")}}"
+	if diffs, _, err := goCodeEvaluatorObj.Verify(gocode, secret); err != nil || len(diffs) != 0 {
+		t.Errorf("Round-Trip Verification of Secret Failed | Got %v %v", diffs, err)
+	}
+	// getDataForSecret panics on an invalid secret-data, Therefore it can't be evaluated
+	gocode = "&corev1.Secret{Data: map[string][]byte{\"key\": getDataForSecret(\"not-base64!\")}}"
+	if _, _, err := goCodeEvaluatorObj.Verify(gocode, secret); err == nil || !strings.Contains(err.Error(), "unable to decode the secret-data") {
+		t.Errorf("Expected error for the invalid secret-data | Got %v", err)
+	}
+}
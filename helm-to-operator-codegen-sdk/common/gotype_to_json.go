@@ -0,0 +1,979 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/tools/go/packages"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+/*
+Go-Type of a Third-Party Kind, As of the Go-Types Config-File
+*/
+type GoTypeConfig struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	GoPackage  string `json:"goPackage"`        // Import-Path of the Go-Package containing the Go-Type
+	GoType     string `json:"goType,omitempty"` // Name of the Go-Type, Defaults to the Kind
+	Alias      string `json:"alias,omitempty"`  // Alias of the Go-Package in the generated go-code, Defaults to <parent-directory><version> (Example: certmanagerv1)
+}
+
+/*
+Go-Types Config-File, Maps the Third-Party Kinds (Group-Version-Kind) to the Go-Types of their published Go-Packages
+Example:
+
+	goModuleDir: ../my-operator
+	types:
+	- apiVersion: k8s.cni.cncf.io/v1
+	  kind: NetworkAttachmentDefinition
+	  goPackage: github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1
+	  alias: nadv1
+
+goModuleDir: Directory of the go-module which requires the Go-Packages (Relative to the Config-File), Defaults to the current directory
+*/
+type GoTypesConfig struct {
+	GoModuleDir string         `json:"goModuleDir,omitempty"`
+	Types       []GoTypeConfig `json:"types"`
+}
+
+/*
+Converts the Third-Party Kinds (whose Go-Type is configured) to the Intermediate-Representation (Json-Map) of the Go-Type,
+Same as of RuntimeJsonConverter, Therefore it can be converted to go-code by JsonStringConverter
+The Go-Packages are loaded using golang.org/x/tools/go/packages, Therefore they don't need to be compiled into the SDK
+*/
+type GoTypeConverter struct {
+	goTypes     map[schema.GroupVersionKind]*types.Named
+	aliases     map[string]string // Import-Path as Key and its Alias (in the generated go-code) as Value, For all the Go-Packages used by the Go-Types
+	enums       []string
+	goModuleDir string // goModuleDir of the Config-File (Resolved)
+	mutex       sync.RWMutex
+}
+
+// Matches the version-directories of the Go-Packages: v1, v1alpha1, v2beta3, ...
+var goPackageVersionRegex = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+/*
+Returns the alias of the Go-Package (If not configured), <parent-directory><version> for the versioned Go-Packages, Otherwise the last directory
+Example: github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1 --> certmanagerv1, github.com/example/api/types --> types
+*/
+func getDerivedAlias(pkgPath string) string {
+	alias := path.Base(pkgPath)
+	if goPackageVersionRegex.MatchString(alias) && path.Dir(pkgPath) != "." {
+		alias = path.Base(path.Dir(pkgPath)) + alias
+	}
+	alias = strings.Map(func(c rune) rune {
+		if c > unicode.MaxASCII || (!unicode.IsLetter(c) && !unicode.IsDigit(c)) {
+			return -1
+		}
+		return unicode.ToLower(c)
+	}, alias)
+	if alias == "" || unicode.IsDigit(rune(alias[0])) {
+		alias = "x" + alias
+	}
+	return alias
+}
+
+/*
+Returns the alias of the import-path, If it is one of the goFileImports
+*/
+func getGoFileImportAlias(pkgPath string) (string, bool) {
+	for _, goImport := range goFileImports {
+		if goImport.path == pkgPath {
+			return goImport.alias, true
+		}
+	}
+	return "", false
+}
+
+/*
+Returns the fully-qualified name of the named data-type (Example: k8s.io/apimachinery/pkg/api/resource.Quantity), Empty for the other data-types
+*/
+func getQualifiedName(t types.Type) string {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+}
+
+/*
+Returns true if the data-type can hold any value (any, []any, map[string]any), Their values are written using the UnstructStringConverter
+*/
+func isUntypedGoType(t types.Type) bool {
+	switch curType := t.Underlying().(type) {
+	case *types.Interface:
+		return true
+	case *types.Slice:
+		_, isInterface := curType.Elem().Underlying().(*types.Interface)
+		return isInterface
+	case *types.Map:
+		_, isInterface := curType.Elem().Underlying().(*types.Interface)
+		return isInterface
+	}
+	return false
+}
+
+type goImporterFunc func(path string) (*types.Package, error)
+
+func (fxn goImporterFunc) Import(path string) (*types.Package, error) {
+	return fxn(path)
+}
+
+/*
+Returns true if the function (or the receiver of the method) has type-parameters
+*/
+func isGenericFxnDecl(fxnDecl *ast.FuncDecl) bool {
+	if fxnDecl.Type.TypeParams != nil {
+		return true
+	}
+	if fxnDecl.Recv == nil || len(fxnDecl.Recv.List) == 0 {
+		return false
+	}
+	recvType := fxnDecl.Recv.List[0].Type
+	if starExpr, ok := recvType.(*ast.StarExpr); ok {
+		recvType = starExpr.X
+	}
+	switch recvType.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return true
+	}
+	return false
+}
+
+/*
+Loads the Go-Packages in the directory (go-module) using golang.org/x/tools/go/packages, Therefore the Go-Packages don't need to be compiled into the SDK
+The Go-Packages (and their dependencies) are type-checked from their source without the function-bodies, Since only the data-types are needed
+Returns the map of Import-Path as Key and the type-checked package as Value, The data-types of a go-package are identical across the returned packages
+*/
+func loadGoPackages(dir string, importPaths []string) (map[string]*types.Package, error) {
+	config := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Dir:  dir,
+		Env:  append(os.Environ(), "CGO_ENABLED=0"), // So that the pure-go files are loaded (No cgo-processing is needed)
+		ParseFile: func(fileSet *token.FileSet, fileName string, src []byte) (*ast.File, error) {
+			file, err := parser.ParseFile(fileSet, fileName, src, parser.SkipObjectResolution)
+			if file != nil {
+				for _, decl := range file.Decls {
+					// The bodies of the generic fxns & init fxns are required by go/types
+					if fxnDecl, ok := decl.(*ast.FuncDecl); ok && !isGenericFxnDecl(fxnDecl) && (fxnDecl.Recv != nil || fxnDecl.Name.Name != "init") {
+						fxnDecl.Body = nil
+					}
+				}
+			}
+			return file, err
+		},
+	}
+	loadedPkgs, err := packages.Load(config, importPaths...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the go-packages %v| %w", importPaths, err)
+	}
+	pkgs := map[string]*types.Package{}
+	for _, pkg := range loadedPkgs {
+		for _, pkgErr := range pkg.Errors {
+			// The imports (& variables) used only by the function-bodies are reported as unused, Which doesn't affect the data-types
+			// Errors in the dependencies don't affect the data-types either, Therefore only the requested Go-Packages are required to be error-free
+			if !strings.Contains(pkgErr.Msg, "and not used") {
+				return nil, fmt.Errorf("unable to load the go-package %s| %w", pkg.PkgPath, pkgErr)
+			}
+		}
+		pkgs[pkg.PkgPath] = pkg.Types
+	}
+	return pkgs, nil
+}
+
+/*
+Recursive Function (DFS Algorithm) to traverse the data-type (and the data-types of its exported fields/elements) and collect the named data-types
+*/
+func collectNamedGoTypes(t types.Type, visited map[types.Type]bool, namedTypes map[*types.Named]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	switch curType := t.(type) {
+	case *types.Named:
+		namedTypes[curType] = true
+		collectNamedGoTypes(curType.Underlying(), visited, namedTypes)
+	case *types.Pointer:
+		collectNamedGoTypes(curType.Elem(), visited, namedTypes)
+	case *types.Slice:
+		collectNamedGoTypes(curType.Elem(), visited, namedTypes)
+	case *types.Array:
+		collectNamedGoTypes(curType.Elem(), visited, namedTypes)
+	case *types.Map:
+		collectNamedGoTypes(curType.Key(), visited, namedTypes)
+		collectNamedGoTypes(curType.Elem(), visited, namedTypes)
+	case *types.Struct:
+		for i := 0; i < curType.NumFields(); i++ {
+			if curType.Field(i).Exported() {
+				collectNamedGoTypes(curType.Field(i).Type(), visited, namedTypes)
+			}
+		}
+	}
+}
+
+/*
+Loads the Go-Types Config-File, So that the configured Third-Party Kinds are converted to their Go-Types
+*/
+func (obj *GoTypeConverter) LoadConfig(configFile string) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the go-types config-file %s| %w", configFile, err)
+	}
+	config := GoTypesConfig{}
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return fmt.Errorf("unable to parse the go-types config-file %s| %w", configFile, err)
+	}
+	goModuleDir := config.GoModuleDir
+	if goModuleDir != "" && !filepath.IsAbs(goModuleDir) {
+		goModuleDir = filepath.Join(filepath.Dir(configFile), goModuleDir)
+	}
+
+	importPaths := map[string]bool{}
+	for i, goTypeConfig := range config.Types {
+		if goTypeConfig.APIVersion == "" || goTypeConfig.Kind == "" || goTypeConfig.GoPackage == "" {
+			return fmt.Errorf("types[%d] of the go-types config-file %s| apiVersion, kind & goPackage are required", i, configFile)
+		}
+		importPaths[goTypeConfig.GoPackage] = true
+	}
+	pkgs, err := loadGoPackages(goModuleDir, sortedKeys(importPaths))
+	if err != nil {
+		return err
+	}
+
+	goTypes := map[schema.GroupVersionKind]*types.Named{}
+	configuredAliases := map[string]string{}
+	namedTypes := map[*types.Named]bool{}
+	visited := map[types.Type]bool{}
+	for _, goTypeConfig := range config.Types {
+		groupVersion, err := schema.ParseGroupVersion(goTypeConfig.APIVersion)
+		if err != nil {
+			return fmt.Errorf("invalid apiVersion %s in the go-types config-file %s| %w", goTypeConfig.APIVersion, configFile, err)
+		}
+		typeName := goTypeConfig.GoType
+		if typeName == "" {
+			typeName = goTypeConfig.Kind
+		}
+		typeObj, ok := pkgs[goTypeConfig.GoPackage].Scope().Lookup(typeName).(*types.TypeName)
+		if !ok {
+			return fmt.Errorf("go-type %s not found in the go-package %s", typeName, goTypeConfig.GoPackage)
+		}
+		named, ok := typeObj.Type().(*types.Named)
+		if _, isStruct := typeObj.Type().Underlying().(*types.Struct); !ok || !isStruct {
+			return fmt.Errorf("go-type %s.%s is not a struct", goTypeConfig.GoPackage, typeName)
+		}
+		goTypes[groupVersion.WithKind(goTypeConfig.Kind)] = named
+		if goTypeConfig.Alias != "" {
+			configuredAliases[goTypeConfig.GoPackage] = goTypeConfig.Alias
+		}
+		collectNamedGoTypes(named, visited, namedTypes)
+	}
+
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	if obj.goTypes == nil {
+		obj.goTypes = map[schema.GroupVersionKind]*types.Named{}
+		obj.aliases = map[string]string{}
+	}
+	if err := obj.setAliases(namedTypes, configuredAliases); err != nil {
+		return err
+	}
+	obj.goModuleDir = goModuleDir
+	for namedType := range namedTypes {
+		// Enums: The named data-types whose underlying kind is basic (Same as of collectEnums)
+		if _, isBasic := namedType.Underlying().(*types.Basic); isBasic && namedType.Obj().Pkg() != nil {
+			obj.enums = append(obj.enums, obj.getTypeName(namedType))
+		}
+	}
+	sort.Strings(obj.enums)
+	for gvk, named := range goTypes {
+		obj.goTypes[gvk] = named
+		logrus.Info("Kind | ", gvk.Kind, " Would Be Converted to Go-Type ", obj.getTypeName(named), " (", named.Obj().Pkg().Path(), ")")
+	}
+	return nil
+}
+
+/*
+Sets the aliases of the Go-Packages of the named data-types: The alias of goFileImports (Example: corev1), Otherwise the configured alias or the derived alias
+The derived aliases are made unique by adding a number (Example: Two Go-Packages ending with meta/v1 --> metav12)
+*/
+func (obj *GoTypeConverter) setAliases(namedTypes map[*types.Named]bool, configuredAliases map[string]string) error {
+	usedAliases := map[string]string{}
+	for _, goImport := range goFileImports {
+		usedAliases[goImport.alias] = goImport.path
+	}
+	for pkgPath, alias := range obj.aliases {
+		usedAliases[alias] = pkgPath
+	}
+	for _, pkgPath := range sortedKeys(configuredAliases) {
+		if _, ok := obj.aliases[pkgPath]; ok {
+			continue
+		}
+		alias := configuredAliases[pkgPath]
+		if usedPath, ok := usedAliases[alias]; ok && usedPath != pkgPath {
+			return fmt.Errorf("alias %s of the go-package %s is already used by %s", alias, pkgPath, usedPath)
+		}
+		obj.aliases[pkgPath] = alias
+		usedAliases[alias] = pkgPath
+	}
+
+	pkgPaths := []string{}
+	for namedType := range namedTypes {
+		if namedType.Obj().Pkg() != nil {
+			pkgPaths = append(pkgPaths, namedType.Obj().Pkg().Path())
+		}
+	}
+	sort.Strings(pkgPaths)
+	for _, pkgPath := range pkgPaths {
+		if _, ok := obj.aliases[pkgPath]; ok {
+			continue
+		}
+		alias, ok := getGoFileImportAlias(pkgPath)
+		if !ok {
+			derivedAlias := getDerivedAlias(pkgPath)
+			alias = derivedAlias
+			for i := 2; usedAliases[alias] != ""; i++ {
+				alias = derivedAlias + strconv.Itoa(i)
+			}
+		}
+		obj.aliases[pkgPath] = alias
+		usedAliases[alias] = pkgPath
+	}
+	return nil
+}
+
+/*
+Returns the data-type as written in the go-code, Along with the alias of its Go-Package (Example: *certmanagerv1.CertificateSpec), Same as of getGoTypeName
+*/
+func (obj *GoTypeConverter) getTypeName(t types.Type) string {
+	switch curType := t.(type) {
+	case *types.Named:
+		if curType.Obj().Pkg() == nil {
+			return curType.Obj().Name()
+		}
+		alias, ok := obj.aliases[curType.Obj().Pkg().Path()]
+		if !ok {
+			alias = getPackageAlias(curType.Obj().Pkg().Path())
+		}
+		return alias + "." + curType.Obj().Name()
+	case *types.Basic:
+		return curType.Name()
+	case *types.Pointer:
+		return "*" + obj.getTypeName(curType.Elem())
+	case *types.Slice:
+		return "[]" + obj.getTypeName(curType.Elem())
+	case *types.Map:
+		return "map[" + obj.getTypeName(curType.Key()) + "]" + obj.getTypeName(curType.Elem())
+	case *types.Interface:
+		return "any"
+	}
+	return t.String()
+}
+
+/*
+Returns the data-type of the field in the Intermediate-Representation,
+The named slices/maps are written as their underlying data-type (Which is assignable to the named data-type), Since JsonStringConverter identifies them by the [] & map prefix
+*/
+func (obj *GoTypeConverter) getIRTypeName(t types.Type) string {
+	if getQualifiedName(t) == "k8s.io/api/core/v1.ResourceList" {
+		return obj.getTypeName(t)
+	}
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Map:
+		return obj.getTypeName(t.Underlying())
+	}
+	return obj.getTypeName(t)
+}
+
+/*
+Returns the Intermediate-Representation of the basic value (string, bool, int, float), The numbers are returned as string (Same as of RuntimeJsonConverter)
+*/
+func getIRBasicValue(basicType *types.Basic, value any) (any, error) {
+	info := basicType.Info()
+	switch {
+	case info&types.IsString != 0:
+		strVal, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, found %v", value)
+		}
+		return strVal, nil
+	case info&types.IsBoolean != 0:
+		boolVal, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected boolean, found %v", value)
+		}
+		return boolVal, nil
+	case info&(types.IsInteger|types.IsFloat) != 0:
+		switch numVal := value.(type) {
+		case int64:
+			return strconv.FormatInt(numVal, 10), nil
+		case float64:
+			if info&types.IsInteger != 0 && numVal != float64(int64(numVal)) {
+				return nil, fmt.Errorf("expected integer, found %v", value)
+			}
+			return strconv.FormatFloat(numVal, 'f', -1, 64), nil
+		}
+		return nil, fmt.Errorf("expected number, found %v", value)
+	}
+	return nil, fmt.Errorf("data-type %s is not supported", basicType.Name())
+}
+
+/*
+Returns true if the value of the data-type can only be written as a field of a struct (Not as an element of a slice/map)
+The JsonStringConverter formats the elements by their data-type, Which doesn't work for the values written as go-code (Example: resource.MustParse("1Gi"))
+*/
+func isFieldOnlyGoType(t types.Type) bool {
+	if pointerType, ok := t.(*types.Pointer); ok {
+		t = pointerType.Elem()
+	}
+	switch getQualifiedName(t) {
+	case "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/runtime.RawExtension",
+		"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON", "encoding/json.RawMessage", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":
+		return true
+	}
+	if basicType, isBasic := t.Underlying().(*types.Basic); isBasic {
+		_, isNamed := t.(*types.Named)
+		return isNamed && basicType.Info()&types.IsNumeric != 0 // Numeric Enums, Their values need to be converted (Example: time.Duration(5))
+	}
+	switch curType := t.Underlying().(type) {
+	case *types.Map, *types.Interface, *types.Array:
+		return true
+	case *types.Slice:
+		if basicType, isBasic := curType.Elem().Underlying().(*types.Basic); isBasic && basicType.Kind() == types.Byte {
+			return false
+		}
+		return isFieldOnlyGoType(curType.Elem())
+	}
+	return false
+}
+
+/*
+Returns the Intermediate-Representation of the value, as per its data-type
+The data-types compiled into the SDK (Example: corev1.PodSpec, resource.Quantity) are converted by the RuntimeJsonConverter (See getCompiledIRValue)
+Struct: {"<FieldName>": {"type": <data-type>, "val": <value>}}, Map: {"<key>": <value>}, Slice: [<value>], Scalars: string/bool
+*/
+func (obj *GoTypeConverter) getIRValue(t types.Type, value any) (any, error) {
+	if compiledType, ok := getCompiledGoTypes()[getQualifiedName(t)]; ok {
+		irVal, err := getCompiledIRValue(compiledType, false, value)
+		if irVal == nil && err == nil {
+			return map[string]any{}, nil // Empty values are omitted by the RuntimeJsonConverter
+		}
+		return irVal, err
+	}
+
+	switch curType := t.Underlying().(type) {
+	case *types.Pointer:
+		return obj.getIRValue(curType.Elem(), value)
+	case *types.Basic:
+		return getIRBasicValue(curType, value)
+	case *types.Slice:
+		if basicType, isBasic := curType.Elem().Underlying().(*types.Basic); isBasic && basicType.Kind() == types.Byte {
+			// []byte is base64 encoded in yaml/json, Which is decoded by getDataForSecret (Same as of RuntimeJsonConverter)
+			if _, ok := value.(string); !ok {
+				return nil, fmt.Errorf("expected base64 string, found %v", value)
+			}
+			return value, nil
+		}
+		if isFieldOnlyGoType(curType.Elem()) {
+			return nil, fmt.Errorf("slice of %s is not supported", obj.getTypeName(curType.Elem()))
+		}
+		sliceVal, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array, found %v", value)
+		}
+		out := []any{}
+		for i, item := range sliceVal {
+			irItem, err := obj.getIRValue(curType.Elem(), item)
+			if err != nil {
+				return nil, fmt.Errorf("index %d| %w", i, err)
+			}
+			if irMap, isMap := irItem.(map[string]any); isMap && len(irMap) == 0 {
+				return nil, fmt.Errorf("index %d| empty object is not supported", i)
+			}
+			out = append(out, irItem)
+		}
+		return out, nil
+	case *types.Map:
+		if obj.getTypeName(curType.Key()) != "string" {
+			return nil, fmt.Errorf("map with key %s is not supported", obj.getTypeName(curType.Key()))
+		}
+		if isFieldOnlyGoType(curType.Elem()) {
+			return nil, fmt.Errorf("map of %s is not supported", obj.getTypeName(curType.Elem()))
+		}
+		if _, isSlice := curType.Elem().Underlying().(*types.Slice); isSlice {
+			return nil, fmt.Errorf("map of %s is not supported", obj.getTypeName(curType.Elem()))
+		}
+		mapVal, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object, found %v", value)
+		}
+		out := map[string]any{}
+		for key, item := range mapVal {
+			irItem, err := obj.getIRValue(curType.Elem(), item)
+			if err != nil {
+				return nil, fmt.Errorf("key %s| %w", key, err)
+			}
+			if irMap, isMap := irItem.(map[string]any); isMap && len(irMap) == 0 {
+				return nil, fmt.Errorf("key %s| empty object is not supported", key)
+			}
+			out[key] = irItem
+		}
+		return out, nil
+	case *types.Struct:
+		mapVal, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object, found %v", value)
+		}
+		usedKeys := map[string]bool{}
+		out, err := obj.getIRStruct(curType, mapVal, usedKeys)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range sortedKeys(mapVal) {
+			if !usedKeys[key] {
+				return nil, fmt.Errorf("unknown field %s (Not found in %s)", key, obj.getTypeName(t))
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("data-type %s is not supported", obj.getTypeName(t))
+}
+
+/*
+Returns the Intermediate-Representation of the struct, The fields having empty values are omitted (Same as of RuntimeJsonConverter)
+The fields of the embedded structs without json-name (Example: metav1.TypeMeta `json:",inline"`) are read from the same value
+usedKeys: Set of the json-names of the fields (Used to find the unknown fields)
+*/
+func (obj *GoTypeConverter) getIRStruct(structType *types.Struct, value map[string]any, usedKeys map[string]bool) (map[string]any, error) {
+	out := map[string]any{}
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		jsonName, _, _ := strings.Cut(reflect.StructTag(structType.Tag(i)).Get("json"), ",")
+		if !field.Exported() || jsonName == "-" {
+			continue
+		}
+		if embeddedStruct, isStruct := field.Type().Underlying().(*types.Struct); isStruct && field.Embedded() && jsonName == "" {
+			embeddedOut, err := obj.getIRStruct(embeddedStruct, value, usedKeys)
+			if err != nil {
+				return nil, err
+			}
+			if len(embeddedOut) != 0 {
+				out[field.Name()] = map[string]any{"type": obj.getTypeName(field.Type()), "val": embeddedOut, "index": i}
+			}
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name()
+		}
+		usedKeys[jsonName] = true
+		fieldVal, ok := value[jsonName]
+		if !ok || fieldVal == nil {
+			continue
+		}
+		irField, err := obj.getIRField(field.Type(), fieldVal)
+		if err != nil {
+			return nil, fmt.Errorf("field %s| %w", jsonName, err)
+		}
+		if irField != nil {
+			irField["index"] = i // The fields are written in the declaration order
+			out[field.Name()] = irField
+		}
+	}
+	return out, nil
+}
+
+/*
+Data-Types compiled into the SDK: The named structs & maps used by the kinds registered in the client-go scheme (Example: corev1.PodSpec, resource.Quantity, metav1.ObjectMeta) & metav1,
+Fully-qualified name (Same as of getQualifiedName) as Key and the data-type as Value
+*/
+var getCompiledGoTypes = sync.OnceValue(func() map[string]reflect.Type {
+	compiledTypes := map[string]reflect.Type{}
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			collect(t.Elem())
+		case reflect.Map, reflect.Struct:
+			qualifiedName := t.PkgPath() + "." + t.Name()
+			if t.Name() == "" || t.PkgPath() == "" {
+				qualifiedName = ""
+			} else if _, ok := compiledTypes[qualifiedName]; ok {
+				return
+			} else {
+				compiledTypes[qualifiedName] = t
+			}
+			if t.Kind() == reflect.Map {
+				collect(t.Elem())
+				return
+			}
+			for i := 0; i < t.NumField(); i++ {
+				if t.Field(i).IsExported() {
+					collect(t.Field(i).Type)
+				}
+			}
+		}
+	}
+	for _, objType := range scheme.Scheme.AllKnownTypes() {
+		collect(objType)
+	}
+	// Data-Types of metav1 which are not used by the built-in kinds, But commonly by the Go-Types
+	collect(reflect.TypeOf(metav1.Duration{}))
+	collect(reflect.TypeOf(metav1.MicroTime{}))
+	return compiledTypes
+})
+
+/*
+Returns the Intermediate-Representation of the value of a data-type compiled into the SDK, As returned by the RuntimeJsonConverter,
+So that the data-types of the built-in kinds are converted the same, whether they are used by a built-in kind or by a Go-Type (Example: corev1.PodTemplateSpec)
+The value is decoded into the data-type (Unknown fields are an error), isPointer: If true, An explicitly set Zero-Value is not omitted
+*/
+func getCompiledIRValue(compiledType reflect.Type, isPointer bool, value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	compiledVal := reflect.New(compiledType)
+	if err := decoder.Decode(compiledVal.Interface()); err != nil {
+		return nil, fmt.Errorf("unable to read the value of %s| %w", getGoTypeName(compiledType), err)
+	}
+	runtimeJsonConverterObj := RuntimeJsonConverter{}
+	if isPointer {
+		return runtimeJsonConverterObj.runDfsJsonOmitEmpty(compiledVal.Interface(), 0), nil
+	}
+	return runtimeJsonConverterObj.runDfsJsonOmitEmpty(compiledVal.Elem().Interface(), 0), nil
+}
+
+/*
+Returns the Intermediate-Representation of the field: {"type": <data-type>, "val": <value>}, nil if the value is empty (Field is omitted)
+The values which can't be represented by their data-type are written as go-code (rawGoCodeType), Example: resource.MustParse("1Gi")
+*/
+func (obj *GoTypeConverter) getIRField(t types.Type, value any) (map[string]any, error) {
+	elemType, isPointer := t, false
+	if pointerType, ok := t.(*types.Pointer); ok {
+		elemType, isPointer = pointerType.Elem(), true
+	}
+	goCode := ""
+	switch getQualifiedName(elemType) {
+	case "k8s.io/apimachinery/pkg/runtime.RawExtension", "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON":
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		goCode = fmt.Sprintf("%s{Raw: []byte(%s)}", obj.getTypeName(elemType), strconv.Quote(string(data)))
+		if isPointer {
+			goCode = "&" + goCode
+		}
+	case "encoding/json.RawMessage":
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		goCode = fmt.Sprintf("json.RawMessage(%s)", strconv.Quote(string(data)))
+	}
+	if goCode != "" {
+		return map[string]any{"type": rawGoCodeType, "val": goCode}, nil
+	}
+
+	if compiledType, ok := getCompiledGoTypes()[getQualifiedName(elemType)]; ok {
+		irVal, err := getCompiledIRValue(compiledType, isPointer, value)
+		if err != nil || irVal == nil {
+			return nil, err
+		}
+		if goCodeVal, isGoCode := irVal.(map[string]string); isGoCode {
+			return map[string]any{"type": rawGoCodeType, "val": goCodeVal["val"]}, nil // Example: resource.MustParse("1Gi")
+		}
+		return map[string]any{"type": obj.getIRTypeName(t), "val": irVal}, nil
+	}
+
+	if isUntypedGoType(t) {
+		// The value is written as go-code, using the UnstructStringConverter (Same as of CrdStructConverter)
+		unstructStringConverterObj := UnstructStringConverter{}
+		return map[string]any{"type": rawGoCodeType, "val": unstructStringConverterObj.runDfsUnstruct(reflect.ValueOf(value), 3)}, nil
+	}
+
+	irVal, err := obj.getIRValue(t, value)
+	if err != nil {
+		return nil, err
+	}
+	switch irValue := irVal.(type) {
+	case map[string]any:
+		if len(irValue) == 0 {
+			return nil, nil
+		}
+	case []any:
+		if len(irValue) == 0 {
+			return nil, nil
+		}
+	case string:
+		if !isPointer && (irValue == "" || irValue == "0") {
+			return nil, nil // Zero-Values are omitted (Same as of RuntimeJsonConverter), Unless the field is a pointer
+		}
+	case bool:
+		if !isPointer && !irValue {
+			return nil, nil
+		}
+	}
+	if basicType, isBasic := elemType.Underlying().(*types.Basic); isBasic && basicType.Info()&types.IsNumeric != 0 {
+		if _, isNamed := elemType.(*types.Named); isNamed {
+			// Numeric Enums are converted explicitly (Example: time.Duration(5)), Since the JsonStringConverter writes Enums as <Enum>("<value>")
+			goCode = fmt.Sprintf("%s(%s)", obj.getTypeName(elemType), irVal)
+			if isPointer {
+				goCode = fmt.Sprintf("ptr.To(%s)", goCode)
+			}
+			return map[string]any{"type": rawGoCodeType, "val": goCode}, nil
+		}
+	}
+	return map[string]any{"type": obj.getIRTypeName(t), "val": irVal}, nil
+}
+
+/*
+Data-Types which are not compiled into the SDK through the kinds of the client-go scheme, But are used by the Go-Types (Fully-qualified name as Key),
+Their methods are needed to write them as json (Example: apiextensionsv1.JSON.MarshalJSON)
+*/
+var knownReflectTypes = map[string]reflect.Type{
+	"encoding/json.RawMessage":                                      reflect.TypeOf(json.RawMessage{}),
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON": reflect.TypeOf(apiextensionsv1.JSON{}),
+	"k8s.io/apimachinery/pkg/runtime.RawExtension":                  reflect.TypeOf(runtime.RawExtension{}),
+}
+
+// Data-Types of the basic kinds (go/types) as Key, Used to build the Go-Types at runtime (See getReflectType)
+var basicReflectTypes = map[types.BasicKind]reflect.Type{
+	types.Bool: reflect.TypeOf(false), types.String: reflect.TypeOf(""), types.Float32: reflect.TypeOf(float32(0)), types.Float64: reflect.TypeOf(float64(0)),
+	types.Int: reflect.TypeOf(int(0)), types.Int8: reflect.TypeOf(int8(0)), types.Int16: reflect.TypeOf(int16(0)), types.Int32: reflect.TypeOf(int32(0)), types.Int64: reflect.TypeOf(int64(0)),
+	types.Uint: reflect.TypeOf(uint(0)), types.Uint8: reflect.TypeOf(uint8(0)), types.Uint16: reflect.TypeOf(uint16(0)), types.Uint32: reflect.TypeOf(uint32(0)), types.Uint64: reflect.TypeOf(uint64(0)),
+}
+
+/*
+Returns the data-type of the Go-Type, Built at runtime using reflect (With the same fields & json-tags), Since the Go-Packages are not compiled into the SDK
+The data-types compiled into the SDK are used as they are (See getCompiledGoTypes), The named data-types are added to goTypes (As written in the go-code)
+The methods of the Go-Types are not built (Example: A custom MarshalJSON), visiting: The named data-types being built (reflect can't build recursive data-types)
+*/
+func (obj *GoTypeConverter) getReflectType(t types.Type, goTypes map[string]reflect.Type, visiting map[*types.Named]bool) (reflect.Type, error) {
+	if compiledType, ok := getCompiledGoTypes()[getQualifiedName(t)]; ok {
+		return compiledType, nil
+	}
+	if knownType, ok := knownReflectTypes[getQualifiedName(t)]; ok {
+		goTypes[obj.getTypeName(t)] = knownType // The GoCodeEvaluator may not know it
+		return knownType, nil
+	}
+	if named, isNamed := t.(*types.Named); isNamed {
+		typeName := obj.getTypeName(named)
+		if goType, ok := goTypes[typeName]; ok {
+			return goType, nil
+		}
+		if visiting[named] {
+			return nil, fmt.Errorf("recursive data-type %s is not supported", typeName)
+		}
+		visiting[named] = true
+		defer delete(visiting, named)
+		goType, err := obj.getReflectType(named.Underlying(), goTypes, visiting)
+		if err != nil {
+			return nil, err
+		}
+		goTypes[typeName] = goType
+		return goType, nil
+	}
+
+	switch curType := t.(type) {
+	case *types.Basic:
+		if basicType, ok := basicReflectTypes[curType.Kind()]; ok {
+			return basicType, nil
+		}
+	case *types.Pointer:
+		elemType, err := obj.getReflectType(curType.Elem(), goTypes, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.PointerTo(elemType), nil
+	case *types.Slice:
+		elemType, err := obj.getReflectType(curType.Elem(), goTypes, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elemType), nil
+	case *types.Array:
+		elemType, err := obj.getReflectType(curType.Elem(), goTypes, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ArrayOf(int(curType.Len()), elemType), nil
+	case *types.Map:
+		keyType, err := obj.getReflectType(curType.Key(), goTypes, visiting)
+		if err != nil {
+			return nil, err
+		}
+		elemType, err := obj.getReflectType(curType.Elem(), goTypes, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.MapOf(keyType, elemType), nil
+	case *types.Interface:
+		return reflect.TypeOf((*any)(nil)).Elem(), nil
+	case *types.Struct:
+		var fields []reflect.StructField
+		for i := 0; i < curType.NumFields(); i++ {
+			field := curType.Field(i)
+			if !field.Exported() {
+				continue
+			}
+			fieldType, err := obj.getReflectType(field.Type(), goTypes, visiting)
+			if err != nil {
+				return nil, err
+			}
+			tag := reflect.StructTag(curType.Tag(i))
+			jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+			// Only the embedded structs without json-name are embedded (Their fields are inlined by encoding/json), Since reflect doesn't support the others
+			fields = append(fields, reflect.StructField{Name: field.Name(), Type: fieldType, Tag: tag, Anonymous: field.Embedded() && jsonName == ""})
+		}
+		return reflect.StructOf(fields), nil
+	}
+	return nil, fmt.Errorf("data-type %s is not supported", obj.getTypeName(t))
+}
+
+/*
+Returns the data-types of the Go-Type of the Kind and of the named data-types it uses (As written in the go-code, Example: nadv1.NetworkAttachmentDefinitionSpec),
+Built at runtime using reflect (See getReflectType), So that the go-code of its resources can be evaluated by the GoCodeEvaluator (Round-Trip Verification)
+*/
+func (obj *GoTypeConverter) GetGoTypes(gvk schema.GroupVersionKind) (goTypes map[string]reflect.Type, err error) {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	named, ok := obj.goTypes[gvk]
+	if !ok {
+		return nil, fmt.Errorf("go-type of the Kind %s is not configured", gvk.Kind)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			// reflect.StructOf panics for the data-types it doesn't support (Example: Embedded data-types having methods)
+			goTypes, err = nil, fmt.Errorf("unable to build the go-type %s| %v", obj.getTypeName(named), r)
+		}
+	}()
+	goTypes = map[string]reflect.Type{}
+	if _, err := obj.getReflectType(named, goTypes, map[*types.Named]bool{}); err != nil {
+		return nil, err
+	}
+	return goTypes, nil
+}
+
+/*
+Returns true if the Go-Type of the Kind is configured (Therefore, Its resources would be converted to the Go-Type)
+*/
+func (obj *GoTypeConverter) IsKnown(gvk schema.GroupVersionKind) bool {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	_, ok := obj.goTypes[gvk]
+	return ok
+}
+
+/*
+Returns the Go-Type of the Kind as written in the go-code (Example: nadv1.NetworkAttachmentDefinition), Empty if the Go-Type of the Kind is not configured
+*/
+func (obj *GoTypeConverter) GetTypeName(gvk schema.GroupVersionKind) string {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	if named, ok := obj.goTypes[gvk]; ok {
+		return obj.getTypeName(named)
+	}
+	return ""
+}
+
+/*
+Returns the imports required by the Go-Types (Alias as Key and Import-Path as Value), Other than goFileImports
+*/
+func (obj *GoTypeConverter) GetImports() map[string]string {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	imports := map[string]string{}
+	for pkgPath, alias := range obj.aliases {
+		if _, ok := getGoFileImportAlias(pkgPath); !ok {
+			imports[alias] = pkgPath
+		}
+	}
+	return imports
+}
+
+/*
+Returns the directory of the go-module which requires the Go-Packages of the Go-Types (goModuleDir of the Config-File), "" for the current directory
+*/
+func (obj *GoTypeConverter) GetGoModuleDir() string {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	return obj.goModuleDir
+}
+
+/*
+Returns the Enums used by the Go-Types (Example: certmanagerv1.PrivateKeyAlgorithm), To be added to the JsonStringConverter
+*/
+func (obj *GoTypeConverter) GetEnums() []string {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	return append([]string{}, obj.enums...)
+}
+
+/*
+Input: Resource (Unstructured) whose Go-Type is configured
+Output: Returns the in-memory Intermediate-Representation (Json-Map) of the resource, Same as of RuntimeJsonConverter
+Error is returned if the resource doesn't match with its Go-Type (Example: Unknown fields)
+*/
+func (obj *GoTypeConverter) Convert(unstructObj unstructured.Unstructured) (map[string]any, error) {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+	named, ok := obj.goTypes[unstructObj.GroupVersionKind()]
+	if !ok {
+		return nil, fmt.Errorf("go-type of the Kind %s is not configured", unstructObj.GetKind())
+	}
+	out, err := obj.getIRValue(named, unstructObj.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalising the Map to Json-Types, Which is what JsonStringConverter expects
+	jsonString, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	var intermediateRepresentation map[string]any
+	if err := json.Unmarshal(jsonString, &intermediateRepresentation); err != nil {
+		return nil, err
+	}
+	return intermediateRepresentation, nil
+}
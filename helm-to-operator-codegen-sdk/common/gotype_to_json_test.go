@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetDerivedAlias(t *testing.T) {
+	tests := []Tests{
+		{"github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1", "certmanagerv1"},
+		{"github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1", "k8scnicncfiov1"},
+		{"github.com/example/api/v1alpha1", "apiv1alpha1"},
+		{"github.com/example/api/types", "types"},
+		{"v1", "v1"},
+	}
+	for _, test := range tests {
+		result := getDerivedAlias(test.input.(string))
+		if result != test.expected.(string) {
+			t.Errorf("GetDerivedAlias Failed | Input %s | Expected %s | Got %s", test.input, test.expected, result)
+		}
+	}
+}
+
+func TestGoTypeConverter(t *testing.T) {
+	var goTypeConverterObj = GoTypeConverter{}
+	if err := goTypeConverterObj.LoadConfig("tests/test-gotypes/go-types.yaml"); err != nil {
+		t.Fatalf("Unable to Load the Go-Types Config | Error %v", err)
+	}
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1beta1", Kind: "Database"}
+	if !goTypeConverterObj.IsKnown(gvk) {
+		t.Fatalf("Configured Kind should be known")
+	}
+	if goTypeConverterObj.IsKnown(schema.GroupVersionKind{Group: "k8s.cni.cncf.io", Version: "v1", Kind: "NetworkAttachmentDefinition"}) {
+		t.Errorf("Kind which is not configured should not be known")
+	}
+	if typeName := goTypeConverterObj.GetTypeName(gvk); typeName != "examplev1beta1.Database" {
+		t.Errorf("Type-Name is not as expected | Got %s", typeName)
+	}
+	if importPath := goTypeConverterObj.GetImports()["examplev1beta1"]; importPath != "helm_to_controller/packages/common/tests/test-gotypes/apis/example/v1beta1" {
+		t.Errorf("Import of the Go-Package is not as expected | Got %v", goTypeConverterObj.GetImports())
+	}
+	if _, ok := goTypeConverterObj.GetImports()["apiextensionsv1"]; ok {
+		t.Errorf("Imports of goFileImports should not be returned | Got %v", goTypeConverterObj.GetImports())
+	}
+	if enums := strings.Join(goTypeConverterObj.GetEnums(), ","); !strings.Contains(enums, "examplev1beta1.Role") {
+		t.Errorf("Enum examplev1beta1.Role Not Found | Got %s", enums)
+	}
+
+	customResources := readUnstructuredObjects(t, "tests/test-crds/custom-resources.yaml")
+	result, err := goTypeConverterObj.Convert(customResources[1])
+	if err != nil {
+		t.Fatalf("Unable to Convert the Custom-Resource | Error %v", err)
+	}
+	spec := result["Spec"].(map[string]any)
+	if spec["type"] != "examplev1beta1.DatabaseSpec" {
+		t.Errorf("Type of Spec is not as expected | Got %v", spec["type"])
+	}
+	specVal := spec["val"].(map[string]any)
+	if replicas := specVal["Replicas"].(map[string]any); replicas["type"] != "*int32" || replicas["val"] != "3" {
+		t.Errorf("Replicas is not as expected | Got %v", replicas)
+	}
+	if size := specVal["Storage"].(map[string]any)["val"].(map[string]any)["Size"]; size.(map[string]any)["val"] != "resource.MustParse(\"10Gi\")" {
+		t.Errorf("Size is not as expected | Got %v", size)
+	}
+
+	var jsonStringConverterObj = JsonStringConverter{}
+	jsonStringConverterObj.Intialise()
+	jsonStringConverterObj.AddEnums(goTypeConverterObj.GetEnums())
+	gocode, err := jsonStringConverterObj.ConvertType(goTypeConverterObj.GetTypeName(gvk), result)
+	if err != nil {
+		t.Fatalf("Unable to Convert the Json to Go-Code | Error %v", err)
+	}
+	for _, expected := range []string{"&examplev1beta1.Database{", "metav1.TypeMeta{", "Enabled  : boolPtr(true)", "Storage  : &examplev1beta1.StorageSpec{",
+		"examplev1beta1.Role(\"write\")", "Extra  : &apiextensionsv1.JSON{Raw: []byte("} {
+		if !strings.Contains(gocode, expected) {
+			t.Errorf("'%s' Not Found in Go-Code| Got %s", expected, gocode)
+		}
+	}
+
+	// The data-types of the built-in kinds are converted by the RuntimeJsonConverter
+	customResources[1].Object["spec"].(map[string]any)["resources"] = map[string]any{"limits": map[string]any{"memory": "1Gi"}}
+	customResources[1].Object["spec"].(map[string]any)["timeout"] = "30s"
+	result, err = goTypeConverterObj.Convert(customResources[1])
+	if err != nil {
+		t.Fatalf("Unable to Convert the Custom-Resource | Error %v", err)
+	}
+	gocode, err = jsonStringConverterObj.ConvertType(goTypeConverterObj.GetTypeName(gvk), result)
+	if err != nil {
+		t.Fatalf("Unable to Convert the Json to Go-Code | Error %v", err)
+	}
+	for _, expected := range []string{"Resources  : corev1.ResourceRequirements{", "\"memory\"  : resource.MustParse(\"1Gi\")",
+		"Timeout  : &metav1.Duration{", "Duration  : time.Duration(30000000000)"} {
+		if !strings.Contains(gocode, expected) {
+			t.Errorf("'%s' Not Found in Go-Code| Got %s", expected, gocode)
+		}
+	}
+	customResources[1].Object["spec"].(map[string]any)["resources"] = map[string]any{"unknown": "value"}
+	if _, err := goTypeConverterObj.Convert(customResources[1]); err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Errorf("Expected error for unknown field of a built-in data-type, Got %v", err)
+	}
+	delete(customResources[1].Object["spec"].(map[string]any), "resources")
+
+	// Custom-Resources not matching with the Go-Type
+	customResources[1].Object["spec"].(map[string]any)["replicas"] = "three"
+	if _, err := goTypeConverterObj.Convert(customResources[1]); err == nil {
+		t.Errorf("Expected error for Custom-Resource not matching with the Go-Type, Got nil")
+	}
+	customResources[1].Object["spec"].(map[string]any)["replicas"] = int64(3)
+	customResources[1].Object["spec"].(map[string]any)["unknown"] = "value"
+	if _, err := goTypeConverterObj.Convert(customResources[1]); err == nil || !strings.Contains(err.Error(), "unknown field unknown") {
+		t.Errorf("Expected error for unknown field, Got %v", err)
+	}
+}
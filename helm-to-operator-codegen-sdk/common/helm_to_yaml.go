@@ -17,45 +17,209 @@ limitations under the License.
 package common
 
 import (
-	"bufio"
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/releaseutil"
 )
 
+// Release-Name used while rendering, Same as the default of "helm template"
+const helmReleaseName = "release-name"
+
+var manifestSourceRegex = regexp.MustCompile(`# Source: (.+)`)
+
 type HelmYamlConvertor struct {
-	Namespace string
-	Chartpath string
+	Namespace      string
+	ReleaseName    string       // Release-Name used while rendering, Defaults to release-name
+	Chartpath      string       // Path to chart-directory, packaged chart-archive (.tgz), OCI reference (oci://registry/chart:version) or chart-name in ChartRepoURL
+	ChartRepoURL   string       // URL of HTTP Chart Repository, If set then Chartpath is the name of the chart in the repository
+	ChartVersion   string       // Version (or version-constraint) of the remote chart, Defaults to latest
+	ChartDigest    string       // Pins the remote chart-archive to the digest (sha256:<hex>)
+	RegistryConfig string       // Docker config file containing the credentials of OCI Registries
+	RepoUsername   string       // Username of the HTTP Chart Repository (ChartRepoURL)
+	RepoPassword   string       // Password of the HTTP Chart Repository (ChartRepoURL)
+	CacheDir       string       // Cache of the pulled remote charts, Defaults to <user-cache-dir>/helm-to-operator-codegen-sdk/charts
+	ValueFiles     []string     // Equivalent of -f/--values, Can be specified multiple times
+	Values         []string     // Equivalent of --set key1=val1,key2=val2
+	StringValues   []string     // Equivalent of --set-string key1=val1,key2=val2
+	FileValues     []string     // Equivalent of --set-file key1=path1,key2=path2
+	Chart          *chart.Chart // The loaded Helm-Chart (Set By RenderManifests)
+}
+
+/*
+Merges the Values-Overrides with the same precedence as of Helm (Later one overrides the previous one):
+values.yaml of chart < ValueFiles (in the order provided) < Values < StringValues < FileValues
+Note: The chart's default values.yaml is merged by helm during rendering, Therefore only overrides are returned
+*/
+func (obj *HelmYamlConvertor) mergeValues() (map[string]any, error) {
+	valueOpts := values.Options{
+		ValueFiles:   obj.ValueFiles,
+		Values:       obj.Values,
+		StringValues: obj.StringValues,
+		FileValues:   obj.FileValues,
+	}
+	return valueOpts.MergeValues(getter.All(cli.New()))
+}
+
+/*
+Resolves the dependencies (mentioned in Chart.yaml) of the chart, which are not already present in charts/ folder
+Dependencies having a file:// repository are loaded from the referenced local chart (relative to chartDir),
+The Resolution is done in-memory, Therefore the chart-directory remains untouched (Unlike helm dependency update)
+It runs recursively over the subcharts and reports a clear error if any subchart is missing
+*/
+func resolveDependencies(chrt *chart.Chart, chartDir string) error {
+	for _, dep := range chrt.Metadata.Dependencies {
+		if action.CheckDependencies(chrt, []*chart.Dependency{dep}) == nil {
+			continue // Already present in charts/ folder
+		}
+		repoPath, isLocalRepo := strings.CutPrefix(dep.Repository, "file://")
+		if !isLocalRepo {
+			return fmt.Errorf("subchart %s (version %q, repository %q) of chart %s is missing| Kindly add it to the charts/ folder of %s",
+				dep.Name, dep.Version, dep.Repository, chrt.Name(), chartDir)
+		}
+		if !filepath.IsAbs(repoPath) {
+			repoPath = filepath.Join(chartDir, repoPath)
+		}
+		subChart, err := loader.Load(repoPath)
+		if err != nil {
+			return fmt.Errorf("subchart %s of chart %s is missing| Unable to load it from %s| %w", dep.Name, chrt.Name(), dep.Repository, err)
+		}
+		if subChart.Name() != dep.Name {
+			return fmt.Errorf("subchart %s of chart %s is missing| Chart found at %s is named %s", dep.Name, chrt.Name(), dep.Repository, subChart.Name())
+		}
+		if dep.Version != "" {
+			constraint, err := semver.NewConstraint(dep.Version)
+			if err != nil {
+				return fmt.Errorf("invalid version %q of subchart %s| %w", dep.Version, dep.Name, err)
+			}
+			version, err := semver.NewVersion(subChart.Metadata.Version)
+			if err != nil || !constraint.Check(version) {
+				return fmt.Errorf("subchart %s of chart %s is missing| Version %s found at %s doesn't satisfy %q",
+					dep.Name, chrt.Name(), subChart.Metadata.Version, dep.Repository, dep.Version)
+			}
+		}
+		logrus.Debug("Resolved Subchart ", dep.Name, " from ", repoPath)
+		chrt.AddDependency(subChart)
+	}
+
+	// Resolving the dependencies of subcharts (Subcharts in charts/ folder are relative to chartDir/charts)
+	for _, subChart := range chrt.Dependencies() {
+		subChartDir := filepath.Join(chartDir, "charts", subChart.Name())
+		for _, dep := range chrt.Metadata.Dependencies {
+			if dep.Name == subChart.Name() && strings.HasPrefix(dep.Repository, "file://") {
+				subChartDir, _ = strings.CutPrefix(dep.Repository, "file://")
+				if !filepath.IsAbs(subChartDir) {
+					subChartDir = filepath.Join(chartDir, subChartDir)
+				}
+			}
+		}
+		if err := resolveDependencies(subChart, subChartDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Loads the Helm-Chart from Chartpath, It could either be a chart-directory, a packaged chart-archive (.tgz)
+or a remote chart (OCI Registry / HTTP Chart Repository), which is pulled to the cache first
+*/
+func (obj *HelmYamlConvertor) loadChart() (*chart.Chart, error) {
+	chartPath, err := obj.locateChart()
+	if err != nil {
+		return nil, err
+	}
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the helm-chart %s| %w", chartPath, err)
+	}
+	// For Chart-Archives, file:// dependencies are resolved relative to the directory containing the archive
+	chartDir := chartPath
+	if info, err := os.Stat(chartPath); err == nil && !info.IsDir() {
+		chartDir = filepath.Dir(chartPath)
+	}
+	if err := resolveDependencies(chrt, chartDir); err != nil {
+		return nil, err
+	}
+	return chrt, nil
 }
 
 /*
-Converts the Helm-Chart to Yaml Template in temp folder,
-Runs the bash command "helm template <chartpath> --namespace <namespace> --output-dir temp/templated/"
-Todo: Increase the functionality to handle remote helm charts, and support for using different values.yaml & so on
+Renders the Helm-Chart in-process using the Helm-Go-SDK (Equivalent of "helm template <chartpath> --namespace <namespace> --include-crds")
+Output: Map of Template-Path (Example: hello-world/templates/deployment.yaml) as Key and the rendered manifest as Value
 */
-func (obj *HelmYamlConvertor) ConvertHelmToYaml() error {
-	logrus.Info(obj.Namespace, " ", obj.Chartpath)
-	logrus.Info(" ----------------- Converting Helm to Yaml --------------------------")
-	_ = createDirIfDontExist("temp")
+func (obj *HelmYamlConvertor) RenderManifests() (map[string]string, error) {
 	if obj.Namespace == "" {
 		obj.Namespace = "default"
 	}
-	cmdStruct := exec.Command("helm", "template", obj.Chartpath, "--namespace", obj.Namespace, "--output-dir", "temp/templated/") // #nosec G204
-	stderr, _ := cmdStruct.StderrPipe()                                                                                           // Intialising a Pipe to read error stream
-	if err := cmdStruct.Start(); err != nil {
-		logrus.Error(err)
-		return err
+	chrt, err := obj.loadChart()
+	if err != nil {
+		return nil, err
+	}
+	obj.Chart = chrt
+
+	vals, err := obj.mergeValues()
+	if err != nil {
+		return nil, fmt.Errorf("unable to merge the values-overrides| %w", err)
 	}
 
-	scanner := bufio.NewScanner(stderr)
-	helmCmdErr := ""
-	for scanner.Scan() {
-		helmCmdErr += scanner.Text()
+	client := action.NewInstall(&action.Configuration{Log: logrus.Debugf})
+	client.DryRun = true
+	client.ClientOnly = true
+	client.Replace = true // Skip the name check
+	client.ReleaseName = obj.ReleaseName
+	if client.ReleaseName == "" {
+		client.ReleaseName = helmReleaseName
 	}
-	if len(helmCmdErr) > 0 {
-		logrus.Error("Error while running the command| helm template " + obj.Chartpath + " --namespace " + obj.Namespace + " --output-dir temp/templated/ ")
-		return fmt.Errorf(helmCmdErr)
+	client.Namespace = obj.Namespace
+	rel, err := client.Run(chrt, vals)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the helm-chart %s| %w", obj.Chartpath, err)
 	}
-	return nil
+
+	manifests := map[string]string{}
+	addManifest := func(sourcePath string, manifest string) {
+		if existing, ok := manifests[sourcePath]; ok {
+			manifests[sourcePath] = existing + "\n---\n" + manifest
+		} else {
+			manifests[sourcePath] = manifest
+		}
+	}
+	// The Release-Manifest contains all the (non-hook) templates, each prefixed with a "# Source: <template-path>" comment
+	splitManifests := releaseutil.SplitManifests(rel.Manifest)
+	manifestKeys := make([]string, 0, len(splitManifests))
+	for key := range splitManifests {
+		manifestKeys = append(manifestKeys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(manifestKeys))
+	for _, key := range manifestKeys {
+		manifest := splitManifests[key]
+		submatch := manifestSourceRegex.FindStringSubmatch(manifest)
+		if len(submatch) == 0 {
+			logrus.Warn("Unable to find the source template of the rendered manifest (Skipping)| ", manifest)
+			continue
+		}
+		addManifest(strings.TrimSpace(submatch[1]), manifest)
+	}
+	// The CRDs of the crds/ folder (of the chart & its subcharts) are installed by helm as well, They are also required to generate the typed go-structs of the Custom-Resources
+	for _, crd := range chrt.CRDObjects() {
+		addManifest(crd.Filename, fmt.Sprintf("# Source: %s\n%s", crd.Filename, string(crd.File.Data)))
+	}
+	// Hooks are not part of Release-Manifest, but "helm template" renders them as well
+	for _, hook := range rel.Hooks {
+		addManifest(hook.Path, fmt.Sprintf("# Source: %s\n%s", hook.Path, hook.Manifest))
+	}
+	return manifests, nil
 }
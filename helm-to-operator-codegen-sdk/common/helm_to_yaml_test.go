@@ -18,14 +18,122 @@ package common
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 )
 
-func TestConvertHelmToYaml(t *testing.T) {
+func TestRenderManifests(t *testing.T) {
 	var helmYamlConvertor = HelmYamlConvertor{Namespace: "myns", Chartpath: "tests/test-helmCharts/hello-world/"}
-	err := helmYamlConvertor.ConvertHelmToYaml()
+	manifests, err := helmYamlConvertor.RenderManifests()
+	if err != nil {
+		t.Fatalf("Unable to render helm-chart in-process | Error %v", err)
+	}
+	deployment, ok := manifests["hello-world/templates/deployment.yaml"]
+	if !ok {
+		t.Fatalf("Rendered Manifests doesn't contain the deployment template | Got %v", manifests)
+	}
+	if !strings.Contains(deployment, "name: release-name-hello-world") {
+		t.Errorf("Release-Name is not same as of helm template in the rendered deployment | Got %s", deployment)
+	}
+	if _, ok := manifests["hello-world/templates/NOTES.txt"]; ok {
+		t.Errorf("NOTES.txt should not be part of rendered manifests")
+	}
+}
+
+func TestRenderManifestsWithValuesOverrides(t *testing.T) {
+	tempDir := t.TempDir()
+	valuesFile1 := filepath.Join(tempDir, "values-1.yaml")
+	valuesFile2 := filepath.Join(tempDir, "values-2.yaml")
+	tagFile := filepath.Join(tempDir, "tag.txt")
+	_ = os.WriteFile(valuesFile1, []byte("replicaCount: 5\nimage:\n  repository: busybox\n"), 0600)
+	_ = os.WriteFile(valuesFile2, []byte("replicaCount: 7\n"), 0600)
+	_ = os.WriteFile(tagFile, []byte("1.2.3"), 0600)
+
+	tests := []Tests{
+		{HelmYamlConvertor{ValueFiles: []string{valuesFile1}}, []string{"replicas: 5", "image: \"busybox:"}},
+		{HelmYamlConvertor{ValueFiles: []string{valuesFile1, valuesFile2}}, []string{"replicas: 7", "image: \"busybox:"}},
+		{HelmYamlConvertor{ValueFiles: []string{valuesFile2}, Values: []string{"replicaCount=9"}}, []string{"replicas: 9"}},
+		{HelmYamlConvertor{Values: []string{"image.tag=4.5"}, StringValues: []string{"image.tag=4.6"}}, []string{"image: \"nginx:4.6\""}},
+		{HelmYamlConvertor{StringValues: []string{"image.tag=4.6"}, FileValues: []string{"image.tag=" + tagFile}}, []string{"image: \"nginx:1.2.3\""}},
+	}
+	for _, test := range tests {
+		helmYamlConvertor := test.input.(HelmYamlConvertor)
+		helmYamlConvertor.Chartpath = "tests/test-helmCharts/hello-world/"
+		manifests, err := helmYamlConvertor.RenderManifests()
+		if err != nil {
+			t.Errorf("Unable to render helm-chart with values-overrides %v | Error %v", test.input, err)
+			continue
+		}
+		deployment := manifests["hello-world/templates/deployment.yaml"]
+		for _, expected := range test.expected.([]string) {
+			if !strings.Contains(deployment, expected) {
+				t.Errorf("Values-Overrides not applied| Input %v | Expected '%s' in %s", test.input, expected, deployment)
+			}
+		}
+	}
+}
+
+func TestRenderManifestsWithInvalidValuesFile(t *testing.T) {
+	var helmYamlConvertor = HelmYamlConvertor{Chartpath: "tests/test-helmCharts/hello-world/", ValueFiles: []string{"tests/non-existing-values.yaml"}}
+	if _, err := helmYamlConvertor.RenderManifests(); err == nil {
+		t.Errorf("Expected error for non-existing values file, Got nil")
+	}
+}
+
+func TestRenderManifestsWithDependencies(t *testing.T) {
+	var helmYamlConvertor = HelmYamlConvertor{Chartpath: "tests/test-helmCharts/umbrella/"}
+	manifests, err := helmYamlConvertor.RenderManifests()
 	if err != nil {
-		t.Errorf("Unable to convert helm-chart to yamls using helm template | Error %v", err)
+		t.Fatalf("Unable to render umbrella helm-chart | Error %v", err)
+	}
+	expectedTemplates := []string{"umbrella/charts/hello-world/templates/deployment.yaml", "umbrella/charts/local-sub/templates/configmap.yaml",
+		"umbrella/charts/local-sub/crds/widget-crd.yaml"}
+	for _, expected := range expectedTemplates {
+		if _, ok := manifests[expected]; !ok {
+			t.Errorf("Subchart Template %s Not Found in Rendered Manifests | Got %v", expected, manifests)
+		}
+	}
+	if !strings.Contains(manifests["umbrella/charts/hello-world/templates/deployment.yaml"], "replicas: 2") {
+		t.Errorf("Values of umbrella chart are not passed to the subchart")
+	}
+	if _, err := os.Stat("tests/test-helmCharts/umbrella/charts/hello-world"); err == nil {
+		t.Errorf("file:// dependency should be resolved in-memory, without modifying the chart-directory")
+	}
+}
+
+func TestRenderManifestsFromChartArchive(t *testing.T) {
+	tempDir := t.TempDir()
+	chrt, err := loader.Load("tests/test-helmCharts/hello-world/")
+	if err != nil {
+		t.Fatalf("Unable to load helm-chart | Error %v", err)
+	}
+	archivePath, err := chartutil.Save(chrt, tempDir)
+	if err != nil {
+		t.Fatalf("Unable to package helm-chart | Error %v", err)
+	}
+	var helmYamlConvertor = HelmYamlConvertor{Chartpath: archivePath}
+	manifests, err := helmYamlConvertor.RenderManifests()
+	if err != nil {
+		t.Fatalf("Unable to render packaged helm-chart %s | Error %v", archivePath, err)
+	}
+	if _, ok := manifests["hello-world/templates/deployment.yaml"]; !ok {
+		t.Errorf("Rendered Manifests of chart-archive doesn't contain the deployment template | Got %v", manifests)
+	}
+}
+
+func TestRenderManifestsWithMissingDependency(t *testing.T) {
+	chartDir := filepath.Join(t.TempDir(), "missing-dep")
+	_ = os.MkdirAll(chartDir, 0750)
+	chartYaml := "apiVersion: v2\nname: missing-dep\nversion: 0.1.0\ndependencies:\n  - name: not-present\n    version: 1.0.0\n    repository: https://charts.example.com\n"
+	_ = os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte(chartYaml), 0600)
+
+	var helmYamlConvertor = HelmYamlConvertor{Chartpath: chartDir}
+	_, err := helmYamlConvertor.RenderManifests()
+	if err == nil || !strings.Contains(err.Error(), "subchart not-present") {
+		t.Errorf("Expected a clear error for missing subchart, Got %v", err)
 	}
-	os.RemoveAll("temp")
 }
@@ -17,154 +17,81 @@ limitations under the License.
 package common
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"go/parser"
 	"reflect"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/liyue201/gostl/ds/set"
-	"github.com/liyue201/gostl/ds/stack"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/scheme"
 )
 
-type JsonStringConverter struct {
-	globalStructMapping map[string]string // To be set by Calling Intialise (setModStructMapping)
-	globalEnumsSet      set.Set[string]   // To be set by Calling Intialise (setEnums)
-	generatedGoCode     string            // To be set by jsonToGoCode Function
-}
-
-func getCorrespondingOpening(closingBrackect rune) rune {
-	switch closingBrackect {
-	case '}':
-		return '{'
-	case ')':
-		return '('
-	case ']':
-		return '['
-	}
-	logrus.Error("Invalid closingBracket ", closingBrackect)
-	return ' '
-}
-
-func (obj *JsonStringConverter) checkOpeningAndClosingBraces() bool {
-	s := stack.New[rune]()
-	lineCount := 0
-	stringDetected := false
-	for _, c := range obj.generatedGoCode {
-		if c == '\n' {
-			lineCount++
-		}
-		if c == '"' {
-			// If Enters in double quotes (string) Don't Check For opening/Closing Braces (because within a string, it's user input which may or may-not be consistent with opening-closing {})
-			stringDetected = !stringDetected
-		}
-		if !stringDetected {
-			switch c {
-			case '{', '(', '[':
-				s.Push(c)
-			case '}', ')', ']':
-				openingBracket := getCorrespondingOpening(c)
-				if s.Empty() {
-					logrus.Errorf("Closing Brace %c has no Opening Brace| Linenumber %d", c, lineCount)
-					return false
-				} else {
-					if openingBracket == s.Top() {
-						s.Pop()
-					} else {
-						logrus.Errorf("Invalid arrangement of brackets detected for %c at Linenumber %d", c, lineCount)
-						return false
-					}
-				}
-			}
-		}
-
-	}
-	for !s.Empty() {
-		logrus.Error("Extra Opening Braces Found at the End ")
-		return false
-	}
+/*
+Data-Type of the Intermediate-Representation, whose value is already go-code (Written as it is)
+Example: The fields of Custom-Resources which don't have a schema (map[string]any{...})
+*/
+const rawGoCodeType = "gocode"
 
-	return true
+type JsonStringConverter struct {
+	globalEnumsSet *set.Set[string] // To be set by Calling Intialise (setEnums)
 }
 
 /*
 Checks for Generated-Go-Code
-Check 1: Opening and Closing Braces should be consistent (as you see in day to day like)
-More Checks can also be added here
+The go-code is parsed (go/parser) as a go-expression, So that a syntactically invalid go-code is caught before it is written to the go-file
 */
-func (obj *JsonStringConverter) checkGoCode() {
-	obj.checkOpeningAndClosingBraces()
+func (obj *JsonStringConverter) checkGoCode(gocode string) error {
+	if _, err := parser.ParseExpr(gocode); err != nil {
+		logrus.Error("Generated Go-Code is not Valid | Error ", err)
+		return fmt.Errorf("generated go-code is not valid| %w", err)
+	}
+	return nil
 }
 
 /*
-Reads the module-structure mapping.json and populate globalStructMapping map
-globalStructMapping Says: Give me any data-type (v1.Deployment) and I will tell you which module it belong (appsv1)
-This is required, because runtime-Object type if you reflect, will say the datatype as v1.Service or v1.DeploymentSpec,
-whereas in code, we want corev1.Service or appsv1.DeploymentSpec
+Recursive Function (DFS Algorithm) to traverse the data-type (and the data-types of its fields/elements) and collect the Enums
+Enums are the named data-types whose underlying kind is basic (string, int, bool, ...), Example: corev1.ServiceType (string)
 */
-func (obj *JsonStringConverter) setModStructMapping(inputFilepath string) {
-
-	out := map[string]string{}
-	plan, _ := os.ReadFile(filepath.Clean(inputFilepath))
-	var data map[string]any
-	err := json.Unmarshal(plan, &data)
-	if err != nil {
-		logrus.Fatal("Cannot unmarshal the json For Struct Mapping | ", inputFilepath, "  Error | ", err)
+func collectEnums(t reflect.Type, visited map[reflect.Type]bool, enums *set.Set[string]) {
+	if visited[t] {
+		return
 	}
-
-	// Validating Struct Mapping, Every Mapping value should contain unique values: pending
-	var validateSet = set.New[string](comparator.StringComparator, set.WithGoroutineSafe())
-	for _, structsList := range data {
-		for _, structName := range structsList.([]any) {
-			// ToDo: What to do when duplication is found, currently we are only logging
-			if validateSet.Contains(structName.(string)) {
-				logrus.Warn("Duplication Detected in Struct Mapping | For ", structName.(string))
-			}
-			validateSet.Insert(structName.(string))
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		collectEnums(t.Elem(), visited, enums)
+	case reflect.Map:
+		collectEnums(t.Key(), visited, enums)
+		collectEnums(t.Elem(), visited, enums)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			collectEnums(t.Field(i).Type, visited, enums)
 		}
-
-	}
-
-	// Saving to Global Map, so that it could be used by "formatTypeVal" function
-	for module, structs := range data {
-		for _, structName := range structs.([]any) {
-			structNameStr := structName.(string)
-			out[structNameStr] = module
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if t.Name() != "" && t.PkgPath() != "" {
+			enums.Insert(getGoTypeName(t))
 		}
 	}
-	obj.globalStructMapping = out
 }
 
 /*
 	 Enums are needed to handle differently than structs, therefore the below set tells which data-types are enum (non-composite),
 		So, that it could be handled differently (Used by "formatTypeVal" function)
+		The set is built from the data-types of all the kinds registered in the client-go scheme (Example: corev1.ServiceType, appsv1.DeploymentStrategyType)
 */
-func (obj *JsonStringConverter) setEnums(inputFilepath string) {
-	fp, _ := os.ReadFile(filepath.Clean(inputFilepath))
-	var data map[string]any
-	err := json.Unmarshal(fp, &data)
-	if err != nil {
-		logrus.Fatal("Cannot unmarshal the json For Enum-Mapping | ", inputFilepath, "  Error | ", err)
-	}
+func (obj *JsonStringConverter) setEnums() {
 	var tempSet = set.New[string](comparator.StringComparator, set.WithGoroutineSafe())
-	// Saving to Global Enum Set, so that it could be used by "formatTypeVal" function
-	for _, enums := range data {
-		for _, val := range enums.([]any) {
-			enum := val.(string)
-			if tempSet.Contains(enum) {
-				// ToDo: What to do when duplication is found, currently we are only logging
-				logrus.Warn("Duplication Detected in Enum Mapping | For ", enum)
-			}
-			tempSet.Insert(enum)
-		}
+	visited := map[reflect.Type]bool{}
+	for _, objType := range scheme.Scheme.AllKnownTypes() {
+		collectEnums(objType, visited, tempSet)
 	}
-	obj.globalEnumsSet = *tempSet
+	obj.globalEnumsSet = tempSet
 }
 
 /*
@@ -198,6 +125,8 @@ func (obj *JsonStringConverter) formatTypeVal(objType string, objVal string, tab
 		// TODO:= to use "k8s.io/utils/pointer" library
 		case "int", "int16", "int32", "int64":
 			return fmt.Sprintf("%sPtr(%s)", afterObjType, objVal[1:len(objVal)-1])
+		case "int8", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			return fmt.Sprintf("ptr.To[%s](%s)", afterObjType, objVal[1:len(objVal)-1])
 		case "bool":
 			return fmt.Sprintf("boolPtr(%s)", objVal)
 		case "string":
@@ -206,7 +135,7 @@ func (obj *JsonStringConverter) formatTypeVal(objType string, objVal string, tab
 	}
 
 	switch objType {
-	case "int32", "int64", "int", "int16":
+	case "int32", "int64", "int", "int16", "int8", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
 		return objVal[1 : len(objVal)-1] // Remove the double quotes and return
 	case "bool":
 		return objVal
@@ -215,39 +144,53 @@ func (obj *JsonStringConverter) formatTypeVal(objType string, objVal string, tab
 	}
 
 	// It will reach here If It is a Composite Literal i.e. Struct OR a Enum
-	// Step-1: If type contains v1 --> Needs to change with corresponding modules using the globalStructMapping
+	// The data-type already contains its module (Example: appsv1.DeploymentSpec), as derived by RuntimeJsonConverter
+	/*
+		The only difference between Enum and Structs is that:
+		Enum are intialised using () where Structs are intialised using {}
+		Therefore, Need to Handle Separatly
+	*/
+	if obj.globalEnumsSet.Contains(afterObjType) { // List of enums([]enumtype) are not in the set, They are Intailised as Structs using {}
+		if pointerType {
+			return fmt.Sprintf("ptr.To(%s(%s))", afterObjType, objVal) // Using ptr.To for Enum-Pointers
+		}
+		return fmt.Sprintf("%s(%s)", afterObjType, objVal) // Replacing {} with (), For Enums
+	}
 	if pointerType {
 		objType = "&" + afterObjType //Converting pointer to address
 	}
-	re := regexp.MustCompile(`v1.`)
-	index := re.FindStringIndex(objType)
-
-	if index != nil {
-		// v1 is present in objType
-		startIndex := index[0]
-		endIndex := index[1]
-		curStruct := objType[endIndex:] // Converts &v1.DeploymentSpec --> DeploymentSpec and assigns to curStruct
-		module := obj.globalStructMapping[curStruct]
-		if module == "" {
-			logrus.Error("Current Structure-Module Mapping is NOT KNOWN| Kindly add it in module_struct_mapping.json | ", objType)
-		}
-		objTypeWithModule := objType[:startIndex] + module + "." + curStruct // Converts &v1.DeploymentSpec --> &appsv1.DeploymentSpec
-		/*
-			The only difference between Enum and Structs is that:
-			Enum are intialised using () where Structs are intialised using {}
-			Therefore, Need to Handle Separatly
-		*/
-		if obj.globalEnumsSet.Contains(curStruct) && !strings.HasPrefix(objType, "[]") { // List of enums([]enumtype) are also Intailised as Structs using {}
-			if pointerType {
-				return fmt.Sprintf("ptr.To(%s(%s))", objTypeWithModule[1:], objVal) // Using ptr.To for Enum-Pointers
-			}
-			return fmt.Sprintf("%s(%s)", objTypeWithModule, objVal) // Replacing {} with (), For Enums
-		} else {
-			return fmt.Sprintf("%s{\n%s\n%s}", objTypeWithModule, objVal, repeat("\t", tabCount))
-		}
+	return fmt.Sprintf("%s{\n%s\n%s}", objType, objVal, repeat("\t", tabCount))
+}
+
+/*
+Returns the position of the field in its struct, As set by the Converters in the Intermediate-Representation: {"type": ..., "val": ..., "index": <position>}
+Returns -1 if the position is not known (Example: The keys of corev1.ResourceList)
+*/
+func getFieldIndex(field any) int {
+	fieldMap, _ := field.(map[string]any)
+	switch index := fieldMap["index"].(type) {
+	case int:
+		return index
+	case float64: // After Normalising the Map to Json-Types
+		return int(index)
 	}
+	return -1
+}
 
-	return fmt.Sprintf("%s{\n%s\n%s}", objType, objVal, repeat("\t", tabCount))
+/*
+Returns the keys (Field-Names) of the Struct-Map in the declaration order of the fields, So that the generated go-code is deterministic
+The keys whose position is not known are sorted alphabetically, after the others
+*/
+func getSortedFieldNames(v reflect.Value) []reflect.Value {
+	mapKeys := v.MapKeys()
+	sort.Slice(mapKeys, func(i, j int) bool {
+		iIndex, jIndex := getFieldIndex(v.MapIndex(mapKeys[i]).Interface()), getFieldIndex(v.MapIndex(mapKeys[j]).Interface())
+		if iIndex != jIndex {
+			return jIndex == -1 || (iIndex != -1 && iIndex < jIndex)
+		}
+		return mapKeys[i].String() < mapKeys[j].String()
+	})
+	return mapKeys
 }
 
 /*
@@ -279,13 +222,18 @@ func (obj *JsonStringConverter) traverseJson(v reflect.Value, curObjType string,
 		return inter_str
 	case reflect.Map:
 		out := ""
-		for _, key := range v.MapKeys() {
+		for _, key := range getSortedFieldNames(v) {
 			// Here key represents the struct Attribute Name/ Field Name
 			objMap, _ := v.MapIndex(key).Interface().(map[string]any)
 			logrus.Debug(repeat("\t", tabs), key)
 			objType := objMap["type"].(string) // objType represents the type of i'th attribute
 			logrus.Debug(repeat("\t", tabs) + objType)
 			objVal := objMap["val"] // objVal represents the value of i'th attribute
+			fieldName := key.String()
+			if curObjType == "corev1.ResourceList" {
+				// Special Case: If type is resourceList, Need Extra Double-Quotes At Key (cpu, ephemoral-storage)
+				fieldName = strconv.Quote(fieldName)
+			}
 			if len(objType) > 5 && (objType[1:4] == "map" || objType[0:3] == "map") {
 				// If objType/ Attribute type is Map. Then it is handled here
 				mapStartIndex := 0
@@ -297,11 +245,12 @@ func (obj *JsonStringConverter) traverseJson(v reflect.Value, curObjType string,
 				curMap := objVal.(map[string]any)
 
 				backTrackValues := ""
-				for curKey, curVal := range curMap {
+				for _, curKey := range sortedKeys(curMap) {
+					curVal := curMap[curKey]
 					logrus.Debug(repeat("\t", tabs), curKey)
 					// Run DFS over the Values of the map that is contained by i'th attribute as its value
 					backtrackVal := obj.traverseJson(reflect.ValueOf(curVal), objType, tabs+1)
-					backTrackValues += fmt.Sprintf("%s\"%s\" : %s,\n", repeat("\t", tabs+1), curKey, obj.formatTypeVal(mapValuesType, backtrackVal, tabs))
+					backTrackValues += fmt.Sprintf("%s%q : %s,\n", repeat("\t", tabs+1), curKey, obj.formatTypeVal(mapValuesType, backtrackVal, tabs))
 				}
 				backTrackValues = backTrackValues[:len(backTrackValues)-1] // Removing the Last Extra Comma
 				out = out + fmt.Sprintf("%s%s : %s,\n", repeat("\t", tabs), key, obj.formatTypeVal(objType, backTrackValues, tabs))
@@ -312,17 +261,13 @@ func (obj *JsonStringConverter) traverseJson(v reflect.Value, curObjType string,
 						"key2": "backtrackVal2"
 					}
 				*/
+			} else if objType == rawGoCodeType {
+				out = out + fmt.Sprintf("%s%s  : %s, \n", repeat("\t", tabs), fieldName, objVal.(string))
 			} else {
 				// If objType/ Attribute type is Not Map, It could be String, Any other Struct, Int, Slice etc
 				// Run DFS over the objVal which is the value of i'th attribute
 				backtrackVal := obj.traverseJson(reflect.ValueOf(objVal), objType, tabs+1)
-				// Special Case: If type is resourceList
-				if curObjType == "v1.ResourceList" {
-					// Need Extra Double-Quotes At Key (cpu, ephemoral-storage)
-					out = out + fmt.Sprintf("%s\"%s\"  : %s, \n", repeat("\t", tabs), key, obj.formatTypeVal(objType, backtrackVal, tabs))
-				} else {
-					out = out + fmt.Sprintf("%s%s  : %s, \n", repeat("\t", tabs), key, obj.formatTypeVal(objType, backtrackVal, tabs))
-				}
+				out = out + fmt.Sprintf("%s%s  : %s, \n", repeat("\t", tabs), fieldName, obj.formatTypeVal(objType, backtrackVal, tabs))
 				/*
 					out would look something Like:
 					Replicas: 32,
@@ -335,6 +280,9 @@ func (obj *JsonStringConverter) traverseJson(v reflect.Value, curObjType string,
 				*/
 			}
 		}
+		if out == "" { // Empty-Struct (Example: &corev1.EmptyDirVolumeSource{})
+			return out
+		}
 		out = out[:len(out)-1] // Removing the last new line
 		return out
 
@@ -344,7 +292,7 @@ func (obj *JsonStringConverter) traverseJson(v reflect.Value, curObjType string,
 		if strings.Contains(data, "\n") {
 			return handleMultiLineStrings(data)
 		}
-		return "\"" + data + "\"" // Need to return the output with double quotes, " --> /"
+		return strconv.Quote(data) // Need to return the output with double quotes (Escaped), " --> \"
 
 	case reflect.Bool:
 		logrus.Debug(repeat("\t", tabs), v.Bool())
@@ -357,55 +305,61 @@ func (obj *JsonStringConverter) traverseJson(v reflect.Value, curObjType string,
 }
 
 /*
-Reads the temp.json created by runtime_to_json.go and traverse json(DFS Runner) and generates the go-code requried
+Traverse the Json-Map (Intermediate-Representation returned by RuntimeJsonConverter) using DFS Runner and generates the go-code requried
 */
-func (obj *JsonStringConverter) jsonToGoCode(inputFilepath string) {
-
-	plan, _ := os.ReadFile(filepath.Clean(inputFilepath))
-	var data map[string]any
-	err := json.Unmarshal(plan, &data)
-	if err != nil {
-		logrus.Error("Cannot unmarshal the json ", err)
-		return
-	}
+func (obj *JsonStringConverter) jsonToGoCode(data map[string]any) string {
 	logrus.Debug("Json Data", data)
-	obj.generatedGoCode = obj.traverseJson(reflect.ValueOf(data), "", 2)
+	generatedGoCode := obj.traverseJson(reflect.ValueOf(data), "", 2)
 
 	logrus.Debug(" --------------Check-Your Go Code --------------------------")
-	logrus.Debug(obj.generatedGoCode)
-	logrus.Debug("Running GO-Code Checks")
-	obj.checkGoCode()
+	logrus.Debug(generatedGoCode)
+	return generatedGoCode
 }
 
 /*
-Intialises the Module-Struct Mapping and Enum-Struct Mapping (Used in Format-Val Function)
+Intialises the Enum-Set (Used in Format-Val Function)
 */
 func (obj *JsonStringConverter) Intialise() {
 	// To Make Sure Intialise is only called once
-	if len(obj.globalStructMapping) == 0 {
-		obj.setModStructMapping("config/struct_module_mapping.json")
-		obj.setEnums("config/enum_module_mapping.json")
+	if obj.globalEnumsSet == nil {
+		obj.setEnums()
 	}
 }
 
 /*
-Reads the temp.json created by runtime_to_json.go and Builds gocode string based on the contents of temps.json
+Adds the Enums, which are not registered in the client-go scheme (Example: Enums of the Go-Types of Third-Party Kinds, As returned by GoTypeConverter.GetEnums)
+Needs to be called after Intialise
 */
-func (obj *JsonStringConverter) Convert(gvk schema.GroupVersionKind) (string, error) {
-	if gvk.Version != "v1" {
-		logrus.Error("Currently Only Api-Version v1 is supported")
-		return "", fmt.Errorf("currently Only Api-Version v1 is supported")
+func (obj *JsonStringConverter) AddEnums(enums []string) {
+	for _, enum := range enums {
+		obj.globalEnumsSet.Insert(enum)
 	}
+}
 
-	module := obj.globalStructMapping[gvk.Kind]
-	if module == "" {
-		logrus.Warn("FATAL ERROR| Kind  " + gvk.Kind + "  Currently Not Supported")
-		return "", fmt.Errorf("FATAL ERROR| Kind  " + gvk.Kind + "  Currently Not Supported")
+/*
+Builds gocode string based on the Json-Map (Intermediate-Representation returned by RuntimeJsonConverter)
+The Converter is only read after Intialise, Therefore it can be used concurrently
+*/
+func (obj *JsonStringConverter) Convert(gvk schema.GroupVersionKind, data map[string]any) (string, error) {
+	runtimeObj, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		logrus.Warn("FATAL ERROR| Kind  " + gvk.Kind + " (Version " + gvk.Version + ")  Currently Not Supported")
+		return "", fmt.Errorf("FATAL ERROR| Kind  " + gvk.Kind + " (Version " + gvk.Version + ")  Currently Not Supported")
 	}
 
-	objType := "&" + module + "." + gvk.Kind
+	return obj.ConvertType(getGoTypeName(reflect.TypeOf(runtimeObj).Elem()), data)
+}
 
-	obj.jsonToGoCode("temp/temp.json")
-	gocode := fmt.Sprintf("%s{\n%s\n\t}", objType, obj.generatedGoCode)
+/*
+Builds gocode string of the data-type (objType) based on the Json-Map, Used for the data-types which are not registered in the client-go scheme
+Example: ConvertType("NetworkAttachmentDefinition", data) --> &NetworkAttachmentDefinition{...} (Typed go-struct of the Custom-Resource, generated by CrdStructConverter)
+Returns an error, If the generated go-code is not a valid go-expression (checkGoCode)
+*/
+func (obj *JsonStringConverter) ConvertType(objType string, data map[string]any) (string, error) {
+	gocode := fmt.Sprintf("&%s{\n%s\n\t}", objType, obj.jsonToGoCode(data))
+	logrus.Debug("Running GO-Code Checks")
+	if err := obj.checkGoCode(gocode); err != nil {
+		return "", err
+	}
 	return gocode, nil
 }
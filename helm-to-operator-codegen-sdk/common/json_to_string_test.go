@@ -17,6 +17,7 @@ limitations under the License.
 package common
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -29,32 +30,34 @@ func TestIntialise(t *testing.T) {
 	ll, _ := logrus.ParseLevel("fatal")
 	logrus.SetLevel(ll)
 
-	moduleStructMappingFile := "../config/struct_module_mapping.json"
-	jsonStringConverterObj.setModStructMapping(moduleStructMappingFile)
-	if len(jsonStringConverterObj.globalStructMapping) == 0 {
-		t.Errorf("Intialise Failed| Unable to Populate Global-Struct-Mapping From Config-File %s", moduleStructMappingFile)
-	}
-
-	enumModuleMapping := "../config/enum_module_mapping.json"
-	jsonStringConverterObj.setEnums(enumModuleMapping)
+	jsonStringConverterObj.Intialise()
 	if jsonStringConverterObj.globalEnumsSet.Size() == 0 {
-		t.Errorf("Intialise Failed| Unable to Populate Enum-Module-Mapping From Config-File %s", enumModuleMapping)
+		t.Errorf("Intialise Failed| Unable to Populate Enum-Set From the client-go scheme")
+	}
+	for _, enum := range []string{"corev1.ServiceType", "appsv1.DeploymentStrategyType", "autoscalingv2.MetricSourceType", "metav1.IncludeObjectPolicy"} {
+		if !jsonStringConverterObj.globalEnumsSet.Contains(enum) {
+			t.Errorf("Intialise Failed| Enum %s Not Found in Enum-Set", enum)
+		}
+	}
+	for _, notEnum := range []string{"corev1.Container", "appsv1.DeploymentSpec", "corev1.ResourceList", "string"} {
+		if jsonStringConverterObj.globalEnumsSet.Contains(notEnum) {
+			t.Errorf("Intialise Failed| %s Should Not be in Enum-Set", notEnum)
+		}
 	}
 }
 
-func TestCheckOpeningAndClosingBraces(t *testing.T) {
+func TestCheckGoCode(t *testing.T) {
 	tests := []Tests{
-		{"{()}[]", true},
-		{"{{}}}", false},
-		{"{([)}", false},
-		{"(", false},
+		{"&corev1.Service{\n\tSpec  : corev1.ServiceSpec{\n\t\tType  : corev1.ServiceType(\"a\\\"b\"), \n\t}, \n\t}", true},
+		{"&corev1.Service{\n\tSpec  : corev1.ServiceSpec{\n\t}}, \n\t}", false},
+		{"&corev1.Service{\n\tName  : \"a\"b\", \n\t}", false},
+		{"&corev1.Service{", false},
 	}
 
 	for _, test := range tests {
-		jsonStringConverterObj.generatedGoCode = test.input.(string)
-		result := jsonStringConverterObj.checkOpeningAndClosingBraces()
+		result := jsonStringConverterObj.checkGoCode(test.input.(string)) == nil
 		if result != test.expected.(bool) {
-			t.Errorf("checkOpeningAndClosingBraces Failed| Expected %t | Got %t | Input %s", test.expected.(bool), result, test.input.(string))
+			t.Errorf("checkGoCode Failed| Expected %t | Got %t | Input %s", test.expected.(bool), result, test.input.(string))
 		}
 	}
 }
@@ -88,19 +91,32 @@ Tests For Composite-Literals (struct, enums, *struct)
 func TestFormatTypeValCompositeCases(t *testing.T) {
 	tests := []Tests{
 		{
-			input: []string{"v1.ObjectMeta", "\tName : \"ABC\""},
+			input: []string{"metav1.ObjectMeta", "\tName : \"ABC\""},
 			expected: `metav1.ObjectMeta{
 	Name : "ABC"
 }`},
 		{
-			input: []string{"*v1.ObjectMeta", "\tName : \"ABC\""},
+			input: []string{"*metav1.ObjectMeta", "\tName : \"ABC\""},
 			expected: `&metav1.ObjectMeta{
 	Name : "ABC"
 }`},
 		{
-			input:    []string{"v1.IncludeObjectPolicy", "\"True\""},
+			input:    []string{"metav1.IncludeObjectPolicy", "\"True\""},
 			expected: "metav1.IncludeObjectPolicy(\"True\")",
 		},
+		{
+			input: []string{"*autoscalingv2.HPAScalingRules", "\tSelectPolicy : \"Min\""},
+			expected: `&autoscalingv2.HPAScalingRules{
+	SelectPolicy : "Min"
+}`},
+		{
+			input:    []string{"autoscalingv2.MetricSourceType", "\"Resource\""},
+			expected: "autoscalingv2.MetricSourceType(\"Resource\")",
+		},
+		{
+			input:    []string{"batchv1beta1.ConcurrencyPolicy", "\"Forbid\""},
+			expected: "batchv1beta1.ConcurrencyPolicy(\"Forbid\")",
+		},
 	}
 
 	for _, test := range tests {
@@ -168,6 +184,24 @@ func TestTraverseJsonCompositeCases(t *testing.T) {
 			},
 			expected: "Labels : map[string]string{\n\t\"label1\" : \"app1\",\n},",
 		},
+		{
+			input: map[string]any{
+				"Command": map[string]any{
+					"type": "string",
+					"val":  `echo "C:\tmp"`,
+				},
+			},
+			expected: `Command  : "echo \"C:\\tmp\"", `,
+		},
+		{
+			// Fields are written in the declaration order (index), The fields without index are sorted alphabetically after them
+			input: map[string]any{
+				"Image":   map[string]any{"type": "string", "val": "nginx", "index": float64(1)},
+				"Name":    map[string]any{"type": "string", "val": "web", "index": float64(0)},
+				"Command": map[string]any{"type": rawGoCodeType, "val": "nil"},
+			},
+			expected: "Name  : \"web\", \nImage  : \"nginx\", \nCommand  : nil, ",
+		},
 	}
 
 	for _, test := range tests {
@@ -182,8 +216,12 @@ func TestTraverseJsonCompositeCases(t *testing.T) {
 }
 
 func TestJsonToGoCode(t *testing.T) {
-	jsonStringConverterObj.jsonToGoCode("tests/expected-json/deployment.json")
-	if jsonStringConverterObj.generatedGoCode == "" {
+	data, _ := GetFileContents("tests/expected-json/deployment.json")
+	var jsonMap map[string]any
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		t.Fatalf("Unable to unmarshal the json | Error %v", err)
+	}
+	if jsonStringConverterObj.jsonToGoCode(jsonMap) == "" {
 		t.Errorf("JsonToGoCode Failed| Unable to Convert JSON To Go-Code")
 	}
 }
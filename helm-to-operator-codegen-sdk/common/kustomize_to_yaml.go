@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+type KustomizeYamlConvertor struct {
+	KustomizationPath string // Directory containing the kustomization.yaml (base or overlay)
+}
+
+/*
+Returns true if the directory contains a kustomization file (kustomization.yaml, kustomization.yml or Kustomization)
+*/
+func IsKustomization(dirPath string) bool {
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		if info, err := os.Stat(filepath.Join(dirPath, fileName)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Runs the kustomization in-process using the krusty API (Equivalent of "kustomize build <kustomization-path>")
+Output: The resulting resources as a multi-document yaml stream (documents separated by ---)
+*/
+func (obj *KustomizeYamlConvertor) RenderManifests() ([]byte, error) {
+	logrus.Info(" ----------------- Running Kustomization ", obj.KustomizationPath, " --------------------------")
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), obj.KustomizationPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run the kustomization %s| %w", obj.KustomizationPath, err)
+	}
+	return resMap.AsYaml()
+}
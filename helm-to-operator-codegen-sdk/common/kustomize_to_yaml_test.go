@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsKustomization(t *testing.T) {
+	tests := []Tests{
+		{"tests/test-kustomize/overlays/site-a", true},
+		{"tests/test-kustomize/base", true},
+		{"tests/test-yamls", false},
+		{"tests/test-helmCharts/hello-world", false},
+	}
+	for _, test := range tests {
+		result := IsKustomization(test.input.(string))
+		if result != test.expected.(bool) {
+			t.Errorf("IsKustomization Failed | Input %s | Expected %v | Got %v", test.input, test.expected, result)
+		}
+	}
+}
+
+func TestKustomizeRenderManifests(t *testing.T) {
+	var kustomizeYamlConvertor = KustomizeYamlConvertor{KustomizationPath: "tests/test-kustomize/overlays/site-a"}
+	manifests, err := kustomizeYamlConvertor.RenderManifests()
+	if err != nil {
+		t.Fatalf("Unable to run the kustomization | Error %v", err)
+	}
+	for _, expected := range []string{"name: site-a-nginx", "replicas: 4", "kind: ConfigMap", "SITE: site-a"} {
+		if !strings.Contains(string(manifests), expected) {
+			t.Errorf("'%s' Not Found in the kustomization output | Got %s", expected, manifests)
+		}
+	}
+
+	kustomizeYamlConvertor = KustomizeYamlConvertor{KustomizationPath: "tests/test-yamls"}
+	if _, err := kustomizeYamlConvertor.RenderManifests(); err == nil {
+		t.Errorf("Expected error for directory without kustomization, Got nil")
+	}
+}
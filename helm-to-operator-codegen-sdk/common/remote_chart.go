@@ -0,0 +1,261 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+/*
+Returns the digest of the chart-archive in the form "sha256:<hex>" (Same as the digest of chart-layer in OCI Registry)
+*/
+func chartDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+/*
+Returns true if the version is an exact version (1.2.3) and not a constraint (~1.2, >=1.0.0) or empty
+Only exact versions (or digests) are served from the cache without contacting the remote
+*/
+func isExactVersion(version string) bool {
+	_, err := semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+	return err == nil
+}
+
+func (obj *HelmYamlConvertor) getCacheDir() (string, error) {
+	if obj.CacheDir != "" {
+		return obj.CacheDir, nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userCacheDir, "helm-to-operator-codegen-sdk", "charts"), nil
+}
+
+/*
+Splits the OCI Reference into repository, tag & digest
+Example: oci://registry/chart:1.2.3 --> (registry/chart, 1.2.3, "")
+
+	oci://registry/chart@sha256:abc --> (registry/chart, "", sha256:abc)
+*/
+func splitOCIReference(ref string) (repository string, tag string, digest string) {
+	repository = strings.TrimPrefix(ref, fmt.Sprintf("%s://", registry.OCIScheme))
+	repository, digest, _ = strings.Cut(repository, "@")
+	lastSlash := strings.LastIndex(repository, "/")
+	if colon := strings.LastIndex(repository, ":"); colon > lastSlash {
+		repository, tag = repository[:colon], repository[colon+1:]
+	}
+	return
+}
+
+/*
+Returns the cache-file path for the chart, Key is built using the source (registry-repository or repo-url & chart-name)
+and version, If the digest is pinned, then the digest is used instead of version (content-addressed)
+*/
+func (obj *HelmYamlConvertor) getCachePath(source string, version string, digest string) (string, error) {
+	cacheDir, err := obj.getCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sourceSum := sha256.Sum256([]byte(source))
+	sourceDir := filepath.Join(cacheDir, hex.EncodeToString(sourceSum[:8]))
+	if digest != "" {
+		return filepath.Join(sourceDir, strings.ReplaceAll(digest, ":", "-")+".tgz"), nil
+	}
+	return filepath.Join(sourceDir, fmt.Sprintf("%s-%s.tgz", filepath.Base(source), version)), nil
+}
+
+/*
+Verifies the chart-archive against ChartDigest (if provided) and saves it to the cache
+*/
+func (obj *HelmYamlConvertor) verifyAndCache(data []byte, cachePath string) error {
+	if obj.ChartDigest != "" && chartDigest(data) != obj.ChartDigest {
+		return fmt.Errorf("digest of the pulled chart %s doesn't match the pinned digest %s", chartDigest(data), obj.ChartDigest)
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(cachePath, data, 0600)
+}
+
+/*
+Returns the cached chart-archive, if it exists (and matches ChartDigest, if provided)
+*/
+func (obj *HelmYamlConvertor) getFromCache(cachePath string) (string, bool) {
+	data, err := os.ReadFile(filepath.Clean(cachePath))
+	if err != nil {
+		return "", false
+	}
+	if obj.ChartDigest != "" && chartDigest(data) != obj.ChartDigest {
+		logrus.Warn("Cached Chart ", cachePath, " doesn't match the pinned digest (Pulling Again)")
+		return "", false
+	}
+	logrus.Info("Using Cached Chart | ", cachePath)
+	return cachePath, true
+}
+
+/*
+Pulls the chart from OCI Registry (oci://registry/chart:version or oci://registry/chart@sha256:digest)
+Credentials are taken from the docker config file (RegistryConfig), Defaults to helm registry config with fallback to ~/.docker/config.json
+*/
+func (obj *HelmYamlConvertor) pullOCIChart() (string, error) {
+	repository, tag, refDigest := splitOCIReference(obj.Chartpath)
+	version := obj.ChartVersion
+	if tag != "" {
+		if version != "" && version != tag {
+			return "", fmt.Errorf("chart version %s and the tag %s of oci reference %s doesn't match", version, tag, obj.Chartpath)
+		}
+		version = tag
+	}
+
+	cacheKeyDigest := refDigest
+	if obj.ChartDigest != "" {
+		cacheKeyDigest = obj.ChartDigest
+	}
+	if cacheKeyDigest != "" || isExactVersion(version) {
+		cachePath, err := obj.getCachePath(repository, version, cacheKeyDigest)
+		if err != nil {
+			return "", err
+		}
+		if path, ok := obj.getFromCache(cachePath); ok {
+			return path, nil
+		}
+	}
+
+	clientOpts := []registry.ClientOption{}
+	if obj.RegistryConfig != "" {
+		clientOpts = append(clientOpts, registry.ClientOptCredentialsFile(obj.RegistryConfig))
+	}
+	client, err := registry.NewClient(clientOpts...)
+	if err != nil {
+		return "", fmt.Errorf("unable to create the registry client| %w", err)
+	}
+
+	pullRef := repository + "@" + refDigest
+	if refDigest == "" {
+		if !isExactVersion(version) {
+			// Resolving the version-constraint (or latest, if empty) from the available tags
+			tags, err := client.Tags(repository)
+			if err != nil {
+				return "", fmt.Errorf("unable to list the tags of %s| %w", repository, err)
+			}
+			if version, err = registry.GetTagMatchingVersionOrConstraint(tags, version); err != nil {
+				return "", err
+			}
+		}
+		pullRef = repository + ":" + version
+	}
+	logrus.Info("Pulling Chart | oci://", pullRef)
+	result, err := client.Pull(pullRef)
+	if err != nil {
+		return "", fmt.Errorf("unable to pull the chart oci://%s| %w", pullRef, err)
+	}
+	if refDigest != "" && result.Manifest.Digest != refDigest {
+		return "", fmt.Errorf("digest of the pulled manifest %s doesn't match the reference %s", result.Manifest.Digest, refDigest)
+	}
+
+	cachePath, err := obj.getCachePath(repository, result.Chart.Meta.Version, cacheKeyDigest)
+	if err != nil {
+		return "", err
+	}
+	if err := obj.verifyAndCache(result.Chart.Data, cachePath); err != nil {
+		return "", err
+	}
+	return cachePath, nil
+}
+
+/*
+Pulls the chart (Chartpath is the chart-name) from the HTTP Chart Repository (ChartRepoURL)
+Credentials (if required) are taken from RepoUsername & RepoPassword
+*/
+func (obj *HelmYamlConvertor) pullRepoChart() (string, error) {
+	source := strings.TrimSuffix(obj.ChartRepoURL, "/") + "/" + obj.Chartpath
+	if obj.ChartDigest != "" || isExactVersion(obj.ChartVersion) {
+		cachePath, err := obj.getCachePath(source, obj.ChartVersion, obj.ChartDigest)
+		if err != nil {
+			return "", err
+		}
+		if path, ok := obj.getFromCache(cachePath); ok {
+			return path, nil
+		}
+	}
+
+	getters := getter.All(cli.New())
+	chartURL, err := repo.FindChartInAuthRepoURL(obj.ChartRepoURL, obj.RepoUsername, obj.RepoPassword, obj.Chartpath, obj.ChartVersion, "", "", "", getters)
+	if err != nil {
+		return "", fmt.Errorf("unable to find the chart %s in repository %s| %w", obj.Chartpath, obj.ChartRepoURL, err)
+	}
+	parsedURL, err := url.Parse(chartURL)
+	if err != nil {
+		return "", err
+	}
+	chartGetter, err := getters.ByScheme(parsedURL.Scheme)
+	if err != nil {
+		return "", err
+	}
+	logrus.Info("Pulling Chart | ", chartURL)
+	// The credentials are sent only if the chart-archive is on the same host as the repository (Same as helm)
+	data, err := chartGetter.Get(chartURL, getter.WithURL(obj.ChartRepoURL), getter.WithBasicAuth(obj.RepoUsername, obj.RepoPassword))
+	if err != nil {
+		return "", fmt.Errorf("unable to pull the chart %s| %w", chartURL, err)
+	}
+
+	// The resolved version is taken from the archive-name (<chart>-<version>.tgz), since the constraint may be provided
+	version := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(parsedURL.Path), obj.Chartpath+"-"), ".tgz")
+	cachePath, err := obj.getCachePath(source, version, obj.ChartDigest)
+	if err != nil {
+		return "", err
+	}
+	if err := obj.verifyAndCache(data.Bytes(), cachePath); err != nil {
+		return "", err
+	}
+	return cachePath, nil
+}
+
+/*
+Returns the local path of the chart, Remote charts (OCI Registry or HTTP Chart Repository) are pulled to the cache first
+The options of the remote charts (ChartDigest, RepoUsername & RepoPassword) are rejected for a local chart, Instead of being ignored
+*/
+func (obj *HelmYamlConvertor) locateChart() (string, error) {
+	if (obj.RepoUsername != "" || obj.RepoPassword != "") && obj.ChartRepoURL == "" {
+		return "", fmt.Errorf("the username & password of the chart-repository can only be used along with a chart-repository (--repo)")
+	}
+	if registry.IsOCI(obj.Chartpath) {
+		return obj.pullOCIChart()
+	} else if obj.ChartRepoURL != "" {
+		return obj.pullRepoChart()
+	}
+	if obj.ChartDigest != "" {
+		return "", fmt.Errorf("the digest %s can only be pinned for a remote chart (OCI Registry or HTTP Chart Repository), Not for the local chart %s", obj.ChartDigest, obj.Chartpath)
+	}
+	return obj.Chartpath, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/repo/repotest"
+)
+
+/*
+Packages the hello-world test-chart and returns the archive-path
+*/
+func packageTestChart(t *testing.T, dir string) string {
+	chrt, err := loader.Load("tests/test-helmCharts/hello-world/")
+	if err != nil {
+		t.Fatalf("Unable to load helm-chart | Error %v", err)
+	}
+	archivePath, err := chartutil.Save(chrt, dir)
+	if err != nil {
+		t.Fatalf("Unable to package helm-chart | Error %v", err)
+	}
+	return archivePath
+}
+
+func TestSplitOCIReference(t *testing.T) {
+	tests := []Tests{
+		{"oci://registry.io/charts/amf:1.2.3", []string{"registry.io/charts/amf", "1.2.3", ""}},
+		{"oci://localhost:5000/amf", []string{"localhost:5000/amf", "", ""}},
+		{"oci://localhost:5000/amf@sha256:abc", []string{"localhost:5000/amf", "", "sha256:abc"}},
+	}
+	for _, test := range tests {
+		repository, tag, digest := splitOCIReference(test.input.(string))
+		expected := test.expected.([]string)
+		if repository != expected[0] || tag != expected[1] || digest != expected[2] {
+			t.Errorf("SplitOCIReference Failed| Input %s | Expected %v | Got [%s %s %s]", test.input, expected, repository, tag, digest)
+		}
+	}
+}
+
+func TestPullRepoChart(t *testing.T) {
+	srvDir := t.TempDir()
+	archivePath := packageTestChart(t, srvDir)
+	index, err := repo.IndexDirectory(srvDir, "")
+	if err != nil {
+		t.Fatalf("Unable to index the chart-repository | Error %v", err)
+	}
+	_ = index.WriteFile(filepath.Join(srvDir, "index.yaml"), 0600)
+	requestCount := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		http.FileServer(http.Dir(srvDir)).ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	archiveData, _ := os.ReadFile(archivePath)
+	var helmYamlConvertor = HelmYamlConvertor{Chartpath: "hello-world", ChartRepoURL: srv.URL, ChartVersion: "0.1.0",
+		ChartDigest: chartDigest(archiveData), CacheDir: t.TempDir()}
+	manifests, err := helmYamlConvertor.RenderManifests()
+	if err != nil {
+		t.Fatalf("Unable to render the chart from chart-repository | Error %v", err)
+	}
+	if _, ok := manifests["hello-world/templates/deployment.yaml"]; !ok {
+		t.Errorf("Rendered Manifests doesn't contain the deployment template | Got %v", manifests)
+	}
+
+	// Second Run should be served from the cache (Offline)
+	srv.Close()
+	requestCountBefore := requestCount
+	if _, err := helmYamlConvertor.RenderManifests(); err != nil {
+		t.Errorf("Unable to render the cached chart | Error %v", err)
+	}
+	if requestCount != requestCountBefore {
+		t.Errorf("Cached chart should not be pulled again")
+	}
+
+	// Digest-Mismatch should fail
+	helmYamlConvertor.ChartDigest = "sha256:0000"
+	helmYamlConvertor.CacheDir = t.TempDir()
+	if _, err := helmYamlConvertor.RenderManifests(); err == nil {
+		t.Errorf("Expected error for digest mismatch, Got nil")
+	}
+}
+
+func TestPullRepoChartWithCredentials(t *testing.T) {
+	srvDir := t.TempDir()
+	packageTestChart(t, srvDir)
+	index, err := repo.IndexDirectory(srvDir, "")
+	if err != nil {
+		t.Fatalf("Unable to index the chart-repository | Error %v", err)
+	}
+	_ = index.WriteFile(filepath.Join(srvDir, "index.yaml"), 0600)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.FileServer(http.Dir(srvDir)).ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	var helmYamlConvertor = HelmYamlConvertor{Chartpath: "hello-world", ChartRepoURL: srv.URL, CacheDir: t.TempDir()}
+	if _, err := helmYamlConvertor.RenderManifests(); err == nil {
+		t.Errorf("Expected error for chart-repository without credentials, Got nil")
+	}
+	helmYamlConvertor.RepoUsername, helmYamlConvertor.RepoPassword = "user", "secret"
+	manifests, err := helmYamlConvertor.RenderManifests()
+	if err != nil {
+		t.Fatalf("Unable to render the chart from chart-repository with credentials | Error %v", err)
+	}
+	if _, ok := manifests["hello-world/templates/deployment.yaml"]; !ok {
+		t.Errorf("Rendered Manifests doesn't contain the deployment template | Got %v", manifests)
+	}
+}
+
+func TestLocalChartWithRemoteOptions(t *testing.T) {
+	tests := []Tests{
+		{HelmYamlConvertor{Chartpath: "tests/test-helmCharts/hello-world/", ChartDigest: "sha256:abc"}, "can only be pinned for a remote chart"},
+		{HelmYamlConvertor{Chartpath: "tests/test-helmCharts/hello-world/", RepoUsername: "user"}, "can only be used along with a chart-repository"},
+	}
+	for _, test := range tests {
+		helmYamlConvertor := test.input.(HelmYamlConvertor)
+		_, err := helmYamlConvertor.RenderManifests()
+		if err == nil || !strings.Contains(err.Error(), test.expected.(string)) {
+			t.Errorf("LocalChartWithRemoteOptions Failed| Input %+v | Expected Error %s | Got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestPullOCIChart(t *testing.T) {
+	srvDir := t.TempDir()
+	srv, err := repotest.NewOCIServer(t, srvDir)
+	if err != nil {
+		t.Fatalf("Unable to create the OCI Registry | Error %v", err)
+	}
+	go srv.ListenAndServe() //nolint:errcheck
+
+	// Logging-in writes the credentials to the docker config file, which is then used by the convertor
+	credentialsFile := filepath.Join(srvDir, "config.json")
+	client, err := registry.NewClient(registry.ClientOptCredentialsFile(credentialsFile))
+	if err != nil {
+		t.Fatalf("Unable to create the registry client | Error %v", err)
+	}
+	if err := client.Login(srv.RegistryURL, registry.LoginOptBasicAuth(srv.TestUsername, srv.TestPassword)); err != nil {
+		t.Fatalf("Unable to login to the OCI Registry | Error %v", err)
+	}
+	archiveData, _ := os.ReadFile(packageTestChart(t, t.TempDir()))
+	pushResult, err := client.Push(archiveData, srv.RegistryURL+"/charts/hello-world:0.1.0")
+	if err != nil {
+		t.Fatalf("Unable to push the chart to OCI Registry | Error %v", err)
+	}
+
+	cacheDir := t.TempDir()
+	tests := []HelmYamlConvertor{
+		{Chartpath: "oci://" + srv.RegistryURL + "/charts/hello-world:0.1.0"},
+		{Chartpath: "oci://" + srv.RegistryURL + "/charts/hello-world", ChartVersion: "~0.1"},
+		{Chartpath: "oci://" + srv.RegistryURL + "/charts/hello-world@" + pushResult.Manifest.Digest},
+		{Chartpath: "oci://" + srv.RegistryURL + "/charts/hello-world:0.1.0", ChartDigest: pushResult.Chart.Digest},
+	}
+	for _, helmYamlConvertor := range tests {
+		helmYamlConvertor.RegistryConfig = credentialsFile
+		helmYamlConvertor.CacheDir = cacheDir
+		manifests, err := helmYamlConvertor.RenderManifests()
+		if err != nil {
+			t.Errorf("Unable to render the chart %s from OCI Registry | Error %v", helmYamlConvertor.Chartpath, err)
+			continue
+		}
+		if _, ok := manifests["hello-world/templates/deployment.yaml"]; !ok {
+			t.Errorf("Rendered Manifests doesn't contain the deployment template | Got %v", manifests)
+		}
+	}
+
+	// Pinned version should be served from the cache, even with wrong credentials
+	offlineConvertor := HelmYamlConvertor{Chartpath: "oci://" + srv.RegistryURL + "/charts/hello-world:0.1.0",
+		CacheDir: cacheDir, RegistryConfig: filepath.Join(t.TempDir(), "config.json")}
+	if _, err := offlineConvertor.RenderManifests(); err != nil {
+		t.Errorf("Unable to render the cached chart | Error %v", err)
+	}
+
+	// Without credentials, the pull should fail
+	unauthorisedConvertor := HelmYamlConvertor{Chartpath: "oci://" + srv.RegistryURL + "/charts/hello-world:0.1.0",
+		CacheDir: t.TempDir(), RegistryConfig: filepath.Join(t.TempDir(), "config.json")}
+	if _, err := unauthorisedConvertor.RenderManifests(); err == nil {
+		t.Errorf("Expected error while pulling without credentials, Got nil")
+	}
+}
@@ -20,26 +20,24 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
-	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/scheme"
 )
 
 type RuntimeJsonConverter struct {
 }
 
+// Helm-Specific-Labels, Which are removed from the Labels of the resources (See refactorHelmLabels)
+var helmSpecificLabels = []string{"helm.sh/chart", "app.kubernetes.io/managed-by"}
+
 func (obj *RuntimeJsonConverter) refactorHelmLabels(labels map[string]any) map[string]any {
 	/*
 		According to helm-k8s docs:
@@ -49,7 +47,6 @@ func (obj *RuntimeJsonConverter) refactorHelmLabels(labels map[string]any) map[s
 		Helm-Specific-Labels are: [helm.sh/chart, app.kubernetes.io/managed-by]
 		ToDo: to check above intution
 	*/
-	helmSpecificLabels := []string{"helm.sh/chart", "app.kubernetes.io/managed-by"}
 	for _, prohibitedLabels := range helmSpecificLabels {
 		_, ok := labels[prohibitedLabels]
 		if ok {
@@ -59,6 +56,38 @@ func (obj *RuntimeJsonConverter) refactorHelmLabels(labels map[string]any) map[s
 	return labels
 }
 
+/*
+Returns the Intermediate-Representation of the Zero-Value which is set explicitly (Non-nil Pointers, Elements of Slices & Values of Maps),
+nil if the value is not a Zero-Value of a basic data-type (or resource.Quantity)
+Example: int32 0 --> "0", bool false --> false, string "" --> "", resource.Quantity 0 --> resource.MustParse("0")
+*/
+func getExplicitZeroValue(v reflect.Value) any {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem() // Values of []any & map[string]any
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if quantity, isQuantity := v.Interface().(resource.Quantity); isQuantity {
+		if !quantity.IsZero() {
+			return nil
+		}
+		return map[string]string{"type": rawGoCodeType, "val": fmt.Sprintf("resource.MustParse(%q)", quantity.String())}
+	}
+	if !v.IsZero() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int16, reflect.Int8, reflect.Int64, reflect.Float32, reflect.Float64:
+		return "0"
+	case reflect.Bool:
+		return false
+	case reflect.String:
+		return ""
+	}
+	return nil
+}
+
 /*
 Recursive Function (DFS Algorithm) to traverse the object structure and identify data-types of various attributes
 If you see the Runtime-Object as a Hierachial Structure (Tree), then you say curObj would be the node of the tree/graph you are currently at
@@ -66,20 +95,38 @@ The DFS Algorithm would traverse all the nodes, but will not return empty fields
 */
 func (obj *RuntimeJsonConverter) runDfsJsonOmitEmpty(curObj any, tabs int) any {
 
+	objRef := reflect.ValueOf(curObj)
+	isPointer := objRef.Kind() == reflect.Ptr
+	if isPointer {
+		if objRef.IsNil() {
+			return nil
+		}
+		objRef = objRef.Elem() // Dereferencing the Pointer
+		if quantity, isQuantity := objRef.Interface().(resource.Quantity); isQuantity {
+			// A non-nil Pointer to a Quantity is set explicitly (Example: averageValue: 500Mi), Therefore even the Zero-Quantity is not omitted
+			return map[string]string{"type": rawGoCodeType, "val": fmt.Sprintf("ptr.To(resource.MustParse(%q))", quantity.String())}
+		}
+		if objRef.IsZero() {
+			// A non-nil Pointer to the Zero-Value is set explicitly (Example: replicas: 0, automountServiceAccountToken: false), Therefore it is not omitted
+			if zeroVal := getExplicitZeroValue(objRef); zeroVal != nil {
+				return zeroVal
+			}
+		}
+		curObj = objRef.Interface() // The Special Cases below are checked against the Dereferenced Value (Example: *metav1.Time)
+	}
+
 	// Handling Special Cases, When We can't move further because of Private Attributes
-	if reflect.TypeOf(curObj).String() == "resource.Quantity" {
-		res := curObj.(resource.Quantity)
-		resourceVal := res.String()
+	if quantity, isQuantity := curObj.(resource.Quantity); isQuantity {
+		resourceVal := quantity.String()
 		if resourceVal == "0" {
 			return nil
 		}
-		// Hack: type is changed to int, because we don't want the value in double quote when converting it to string
-		return map[string]string{"type": "int", "val": fmt.Sprintf("resource.MustParse(\"%s\")", resourceVal)}
-	} else if reflect.TypeOf(curObj).String() == "v1.Time" {
+		// The value is written as go-code, because the attributes of resource.Quantity are private
+		return map[string]string{"type": rawGoCodeType, "val": fmt.Sprintf("resource.MustParse(%q)", resourceVal)}
+	} else if timeInter, isTime := curObj.(metav1.Time); isTime {
 		/*
 			Since the attributes of v1.Time struct are private, Therefore we need to send back the value using GoString() method
 		*/
-		timeInter := curObj.(metav1.Time)
 		timeVar := timeInter.Time
 		defaultVal := time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
 		if timeVar == defaultVal { //If The LHS is the Default Value, then omit it
@@ -87,17 +134,21 @@ func (obj *RuntimeJsonConverter) runDfsJsonOmitEmpty(curObj any, tabs int) any {
 		} else {
 			var out = make(map[string]any)
 			timeVal := timeVar.GoString()
-			out["Time"] = map[string]string{"type": "int", "val": timeVal} // Hack: type is changed to int, because we don't want the value in double quote
+			out["Time"] = map[string]string{"type": rawGoCodeType, "val": timeVal} // The value is written as go-code (time.Date(...))
 			return out
 		}
+	} else if microTime, isMicroTime := curObj.(metav1.MicroTime); isMicroTime {
+		// v1.MicroTime also contains only the Time field, Therefore it is written the same as v1.Time
+		return obj.runDfsJsonOmitEmpty(metav1.Time{Time: microTime.Time}, tabs)
+	} else if duration, isDuration := curObj.(metav1.Duration); isDuration {
+		if duration.Duration == 0 {
+			return nil
+		}
+		// time.Duration is a numeric enum, Therefore it is written as go-code (time.Duration(...))
+		return map[string]any{"Duration": map[string]string{"type": rawGoCodeType, "val": fmt.Sprintf("time.Duration(%d)", int64(duration.Duration))}}
 	}
 	// Private Attributes Special Cases Handling  End
 
-	objRef := reflect.ValueOf(curObj)
-	if objRef.Kind() == reflect.Ptr {
-		objRef = objRef.Elem() // Dereferencing the Pointer
-	}
-
 	switch objRef.Kind() {
 	case reflect.Struct:
 		var out = make(map[string]any)
@@ -110,16 +161,30 @@ func (obj *RuntimeJsonConverter) runDfsJsonOmitEmpty(curObj any, tabs int) any {
 			// Run DFS over the attributes (Fields) of current Struct
 			backtrackVal := obj.runDfsJsonOmitEmpty(objRef.Field(i).Interface(), tabs+1)
 			if backtrackVal != nil {
-				inter["type"] = objRef.Type().Field(i).Type.String() // Type of i'th Field
-				inter["val"] = backtrackVal                          // Backtracked/Actual Value of i'th Field
+				inter["type"] = getGoTypeName(objRef.Type().Field(i).Type) // Type of i'th Field (Along with its package, Example: appsv1.DeploymentSpec)
+				inter["val"] = backtrackVal                                // Backtracked/Actual Value of i'th Field
+				inter["index"] = i                                         // Position of i'th Field in the struct (The fields are written in the declaration order)
+				if goCode, isGoCode := backtrackVal.(map[string]string); isGoCode && goCode["type"] == rawGoCodeType {
+					// The Field is written as go-code (Example: resource.Quantity), Therefore its value is the go-code itself
+					inter["type"] = rawGoCodeType
+					inter["val"] = goCode["val"]
+				}
 				attributeName := objRef.Type().Field(i).Name
 				if attributeName == "Labels" {
-					inter["val"] = obj.refactorHelmLabels(backtrackVal.(map[string]any))
+					labels := obj.refactorHelmLabels(backtrackVal.(map[string]any))
+					if len(labels) == 0 { // Only the helm-labels were present
+						continue
+					}
+					inter["val"] = labels
 				}
 				out[attributeName] = inter // Save the (Type and Value of i'th Field) with key as i'th Field Name
 			}
 		}
 		if len(out) == 0 {
+			if isPointer {
+				// A non-nil Pointer to an Empty-Struct is set explicitly (Example: emptyDir: {}), Therefore it is written as &T{}
+				return out
+			}
 			return nil
 		}
 		return out
@@ -149,10 +214,7 @@ func (obj *RuntimeJsonConverter) runDfsJsonOmitEmpty(curObj any, tabs int) any {
 		if data == "" { // "" is considered to be default value, Therefore, Omitting it
 			return nil
 		}
-		// Todo: Need much better handling to strings, Since Different combinations can lead to bad-buggy results
-		// Below Additional Replace helps in building integrity of the "" string
-		data = strings.ReplaceAll(data, "\\", "\\\\") // Replacing String containing \ with \\
-		data = strings.ReplaceAll(data, "\"", "\\\"") // Replacing String containing " with \"
+		// The string is escaped (strconv.Quote) by the JsonStringConverter, Therefore it is returned as it is
 		return data
 	case reflect.Slice:
 		var out []any
@@ -173,6 +235,10 @@ func (obj *RuntimeJsonConverter) runDfsJsonOmitEmpty(curObj any, tabs int) any {
 		for i := 0; i < objRef.Len(); i++ {
 			// Run DFS over the all the iterations of current slice and capture the backtrack value
 			backtrackVal := obj.runDfsJsonOmitEmpty(objRef.Index(i).Interface(), tabs+1)
+			if backtrackVal == nil {
+				// The elements of a slice are always set explicitly, Therefore the Zero-Values (Example: runAsGroups: [0]) are not omitted
+				backtrackVal = getExplicitZeroValue(objRef.Index(i))
+			}
 			if backtrackVal != nil {
 				out = append(out, backtrackVal)
 			} else {
@@ -196,8 +262,10 @@ func (obj *RuntimeJsonConverter) runDfsJsonOmitEmpty(curObj any, tabs int) any {
 			for _, key := range objRef.MapKeys() {
 				// Run DFS over all the Values of current Map and Capture the Output
 				backtrackVal := obj.runDfsJsonOmitEmpty(objRef.MapIndex(key).Interface(), tabs+1)
-				// Argument: Why would someone add "" value of a key, if it is not useful i.e (key1 : ""),
-				// Pending: Type Checking, Todo: if val_type is int, then add 0, if bool, then add false, if string then, "" (Required Or Not)
+				if backtrackVal == nil {
+					// The values of a map are always set explicitly, Therefore the Zero-Values (Example: annotations: {key: ""}, limits: {cpu: 0}) are not omitted
+					backtrackVal = getExplicitZeroValue(objRef.MapIndex(key))
+				}
 				if backtrackVal != nil {
 					out[key.String()] = backtrackVal
 				}
@@ -222,7 +290,8 @@ func (obj *RuntimeJsonConverter) runDfsJsonOmitEmpty(curObj any, tabs int) any {
 
 /*
 Input: Runtime-Obj, Group-Version-Kind
-Output: Writes Temp.json which represents the structure(Heirarchy) and corresponding data-types & values of the Runtime-Object
+Output: Returns the in-memory Intermediate-Representation (Json-Map) which represents the structure(Heirarchy) and corresponding data-types & values of the Runtime-Object
+The Converter doesn't have any state, Therefore it can be used concurrently
 Example:
 
 	{
@@ -231,7 +300,7 @@ Example:
 			"val" : "v1"
 		},
 		"Spec": {
-			"type": "v1.DeploymentSpec"
+			"type": "appsv1.DeploymentSpec"
 			"val": {
 				"Replicas": {
 					"type" : "&int32",
@@ -241,63 +310,26 @@ Example:
 		}
 	} & so on
 */
-func (obj *RuntimeJsonConverter) Convert(runtimeObj runtime.Object, gvk schema.GroupVersionKind) error {
-	if gvk.Version != "v1" {
-		logrus.Error("Currently Only Api-Version v1 is supported (Skipping)| Given Version " + gvk.Version)
-		return fmt.Errorf("currently only Api-version v1 is supported| Given Version " + gvk.Version)
-	}
+func (obj *RuntimeJsonConverter) Convert(runtimeObj runtime.Object, gvk schema.GroupVersionKind) (map[string]any, error) {
 	logrus.Debug("----------------------------------Your Runtime Object--------------------\n", runtimeObj)
 	logrus.Debug("----------------------------------Your Runtime Object Ends--------------------\n")
-	var objMap any
-	switch gvk.Kind {
-	case "Service":
-		curObj := runtimeObj.(*corev1.Service)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "Deployment":
-		curObj := runtimeObj.(*appsv1.Deployment)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "ConfigMap":
-		curObj := runtimeObj.(*corev1.ConfigMap)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "ServiceAccount":
-		curObj := runtimeObj.(*corev1.ServiceAccount)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "PersistentVolumeClaim":
-		curObj := runtimeObj.(*corev1.PersistentVolumeClaim)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "StatefulSet":
-		curObj := runtimeObj.(*appsv1.StatefulSet)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "Secret":
-		curObj := runtimeObj.(*corev1.Secret)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "PriorityClass":
-		curObj := runtimeObj.(*schedulingv1.PriorityClass)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "Role":
-		curObj := runtimeObj.(*rbacv1.Role)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "RoleBinding":
-		curObj := runtimeObj.(*rbacv1.RoleBinding)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "ClusterRole":
-		curObj := runtimeObj.(*rbacv1.ClusterRole)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	case "ClusterRoleBinding":
-		curObj := runtimeObj.(*rbacv1.ClusterRoleBinding)
-		objMap = obj.runDfsJsonOmitEmpty(curObj, 0)
-	default:
+	if _, err := scheme.Scheme.New(gvk); err != nil {
 		logrus.Warn("Kind Currently Not Supported  | ", gvk.Kind)
-		return fmt.Errorf("kind Currently Not Supported  | %s", gvk.Kind)
+		return nil, fmt.Errorf("kind Currently Not Supported  | %s", gvk.Kind)
 	}
+	// The DFS traversal is generic (reflect based), Therefore any kind registered in the client-go scheme can be converted
+	objMap := obj.runDfsJsonOmitEmpty(runtimeObj, 0)
 
 	logrus.Debug("----------------------------------Your JSON Map--------------------\n", objMap)
 	logrus.Debug("----------------------------------Your JSON Map Ends--------------------\n")
-	jsonString, jsonErr := json.MarshalIndent(objMap, "", "    ")
+	// Normalising the Map to Json-Types (map[string]any, []any, string, float64, bool), Which is what JsonStringConverter expects
+	jsonString, jsonErr := json.Marshal(objMap)
 	if jsonErr != nil {
-		return jsonErr
+		return nil, jsonErr
+	}
+	var intermediateRepresentation map[string]any
+	if err := json.Unmarshal(jsonString, &intermediateRepresentation); err != nil {
+		return nil, err
 	}
-	_ = createDirIfDontExist("temp")
-	err := os.WriteFile("temp/temp.json", jsonString, 0600)
-	return err
+	return intermediateRepresentation, nil
 }
@@ -20,13 +20,17 @@ import (
 	"encoding/json"
 	"os"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/utils/ptr"
 )
 
 var runtimeJsonConverterObj = RuntimeJsonConverter{}
@@ -47,46 +51,55 @@ func TestConvert(t *testing.T) {
 	if err != nil {
 		t.Errorf("Unable to Decode the Yaml| %s", inputFile)
 	}
-	err = runtimeJsonConverterObj.Convert(runtimeObject, *gvk)
+	result, err := runtimeJsonConverterObj.Convert(runtimeObject, *gvk)
 	if err != nil {
 		t.Errorf("Unable to Convert Runtime-Obj to JSON | Error %v", err)
 	}
-	resultFile := "temp/temp.json"
 	expectedFile := "tests/expected-json/deployment.json"
-	resultData, _ := GetFileContents(resultFile)
 	expectedData, _ := GetFileContents(expectedFile)
 
-	var result any
-	var expected any
-	_ = json.Unmarshal([]byte(resultData), &result)
+	var expected map[string]any
 	_ = json.Unmarshal([]byte(expectedData), &expected)
 	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("Result Doesn't Matches with Expected| Kindly Check |\n ResultFile %s \t| ExpectedFile %s\n", resultFile, expectedFile)
+		t.Errorf("Result Doesn't Matches with Expected| Kindly Check |\n ExpectedFile %s\n", expectedFile)
 	}
 	// ----------------- Testing for JSON to GoCode --------------------------
 	var jsonStringConverterObj = JsonStringConverter{}
 	ll, _ := logrus.ParseLevel("fatal")
 	logrus.SetLevel(ll)
 
-	moduleStructMappingFile := "../config/struct_module_mapping.json"
-	jsonStringConverterObj.setModStructMapping(moduleStructMappingFile)
-	if len(jsonStringConverterObj.globalStructMapping) == 0 {
-		t.Errorf("Intialise Failed| Unable to Populate Global-Struct-Mapping From Config-File %s", moduleStructMappingFile)
-	}
-
-	enumModuleMapping := "../config/enum_module_mapping.json"
-	jsonStringConverterObj.setEnums(enumModuleMapping)
-	if jsonStringConverterObj.globalEnumsSet.Size() == 0 {
-		t.Errorf("Intialise Failed| Unable to Populate Enum-Module-Mapping From Config-File %s", enumModuleMapping)
-	}
-	gocode, err := jsonStringConverterObj.Convert(*gvk)
+	jsonStringConverterObj.Intialise()
+	gocode, err := jsonStringConverterObj.Convert(*gvk, result)
 	if err != nil {
 		t.Errorf("Error encountered while converting json to gocode | Error %v", err)
 	}
 	if gocode == "" {
 		t.Error("Empty Go-Code returned While Converting json to gocode-string")
 	}
-	os.RemoveAll("temp")
+	if _, err := os.Stat("temp"); err == nil {
+		t.Errorf("Converters should not write to the working directory")
+	}
+
+	// ----------------- Testing for Concurrent Use of the Converters --------------------------
+	var wg sync.WaitGroup
+	concurrentGocodes := make([]string, 8)
+	for i := range concurrentGocodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			jsonMap, err := runtimeJsonConverterObj.Convert(runtimeObject, *gvk)
+			if err != nil {
+				return
+			}
+			concurrentGocodes[i], _ = jsonStringConverterObj.Convert(*gvk, jsonMap)
+		}(i)
+	}
+	wg.Wait()
+	for i, concurrentGocode := range concurrentGocodes {
+		if concurrentGocode != gocode {
+			t.Errorf("Go-Code generated concurrently (Goroutine %d) differs from the sequential one", i)
+		}
+	}
 }
 
 /*
@@ -113,11 +126,32 @@ func TestRunDfsJsonOmitEmptyBaseCases(t *testing.T) {
 Tests For Complex Cases in DFS Traversal (Struct, Slices, Maps)
 */
 func TestRunDfsJsonOmitEmptyComplexCases(t *testing.T) {
+	falseVal, zeroVal := false, int64(0)
 	tests := []Tests{
 		{[]string{"abc", "def", ""}, []any{"abc", "def", ""}},
-		{[]byte("my-secret"), "bXktc2VjcmV0"}, //Base64 encoded version of my-secret// This is also a TODO task (to check if it is important or not)
-		// {[]any{0, "abc"}, []any{"", "abc"}},// This is TODO Task
+		{[]byte("my-secret"), "bXktc2VjcmV0"},              //Base64 encoded version of my-secret// This is also a TODO task (to check if it is important or not)
+		{[]any{0, "abc", false}, []any{"0", "abc", false}}, // Zero-Values in a slice are set explicitly
+		{map[string]string{"key1": "", "key2": "abc"}, map[string]any{"key1": "", "key2": "abc"}},
+		{corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0")}, map[string]any{"cpu": map[string]string{"type": rawGoCodeType, "val": "resource.MustParse(\"0\")"}}},
+		{
+			// Non-nil Pointers to Zero-Values are set explicitly, Whereas nil Pointers are omitted
+			input: corev1.SecurityContext{Privileged: &falseVal, RunAsUser: &zeroVal},
+			expected: map[string]any{
+				"Privileged": map[string]any{"type": "*bool", "val": false, "index": 1},
+				"RunAsUser":  map[string]any{"type": "*int64", "val": "0", "index": 4},
+			},
+		},
 		{metav1.ObjectMeta{}, nil}, //Empty Struct Should Return Nil
+		{
+			// Non-nil Pointers to Empty-Structs are set explicitly (emptyDir: {}), Therefore they are not omitted
+			input: corev1.Volume{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			expected: map[string]any{
+				"Name": map[string]any{"type": "string", "val": "tmp", "index": 0},
+				"VolumeSource": map[string]any{"type": "corev1.VolumeSource", "index": 1, "val": map[string]any{
+					"EmptyDir": map[string]any{"type": "*corev1.EmptyDirVolumeSource", "val": map[string]any{}, "index": 1},
+				}},
+			},
+		},
 		{
 			input: map[string]any{
 				"key1": "abc",
@@ -135,12 +169,14 @@ func TestRunDfsJsonOmitEmptyComplexCases(t *testing.T) {
 			},
 			expected: map[string]any{
 				"Name": map[string]any{
-					"type": "string",
-					"val":  "tests",
+					"type":  "string",
+					"val":   "tests",
+					"index": 0,
 				},
 				"Generation": map[string]any{
-					"type": "int64",
-					"val":  "2",
+					"type":  "int64",
+					"val":   "2",
+					"index": 6,
 				},
 			},
 		},
@@ -155,7 +191,7 @@ func TestRunDfsJsonOmitEmptyComplexCases(t *testing.T) {
 }
 
 /*
-Tests For Special Cases in DFS Traversal (resource.Quantity, v1.Time)
+Tests For Special Cases in DFS Traversal (resource.Quantity, *resource.Quantity, v1.Time)
 */
 func TestRunDfsJsonOmitEmptySpecialCases(t *testing.T) {
 	tests := []Tests{
@@ -163,13 +199,28 @@ func TestRunDfsJsonOmitEmptySpecialCases(t *testing.T) {
 		{metav1.Time{}, nil},
 		{
 			input:    resource.MustParse("64Mi"),
-			expected: map[string]string{"type": "int", "val": "resource.MustParse(\"64Mi\")"},
+			expected: map[string]string{"type": rawGoCodeType, "val": "resource.MustParse(\"64Mi\")"},
+		},
+		{
+			input:    ptr.To(resource.MustParse("500Mi")), // Example: averageValue of autoscalingv2.MetricTarget
+			expected: map[string]string{"type": rawGoCodeType, "val": "ptr.To(resource.MustParse(\"500Mi\"))"},
+		},
+		{
+			input:    ptr.To(resource.MustParse("0")), // A non-nil Pointer to the Zero-Quantity is set explicitly
+			expected: map[string]string{"type": rawGoCodeType, "val": "ptr.To(resource.MustParse(\"0\"))"},
+		},
+		{
+			input: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: ptr.To(resource.MustParse("500Mi"))},
+			expected: map[string]any{
+				"Type":         map[string]any{"type": "autoscalingv2.MetricTargetType", "val": "AverageValue", "index": 0},
+				"AverageValue": map[string]any{"type": rawGoCodeType, "val": "ptr.To(resource.MustParse(\"500Mi\"))", "index": 2},
+			},
 		},
 		{
 			input: metav1.Time{Time: time.Time.AddDate(time.Time{}, 2, 3, 0)},
 			expected: map[string]any{
 				"Time": map[string]string{
-					"type": "int",
+					"type": rawGoCodeType,
 					"val":  time.Time.AddDate(time.Time{}, 2, 3, 0).GoString(),
 				},
 			},
@@ -18,19 +18,68 @@ package common
 
 import (
 	"fmt"
+	"go/format"
+	"go/scanner"
+	"go/token"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/liyue201/gostl/ds/set"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/releaseutil"
 )
 
+// Defaults of the Generated Go-File(s), If not provided
+const (
+	defaultGoFilePackageName = "controller"
+	defaultGoFileOutputDir   = "outputs"
+	defaultGoFileName        = "generated_code.go"
+	defaultFieldManager      = "helm-to-operator-codegen-sdk"
+	valuesTypesFileName      = "values_types.go"
+)
+
+// Label set on the cluster-scoped resources owned by a namespaced owner (Value: UID of the owner), Used by CleanupClusterScoped of the generated go-code
+const ownerLabel = "nephio.org/owner-uid"
+
+// Fxns operating on all the resources (getMasterFxn): Usage (Apply, Create, Delete) as Key, The call of the client (on object) & the doc-comment of the fxn as Value
+var masterFxns = map[string]struct {
+	call string
+	doc  string
+}{
+	"Apply": {"c.Patch(ctx, object, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)",
+		"ApplyAll applies all the resources of the generated go-code using server-side apply (Field-Manager: FieldManager), Therefore it can be called on every reconcile"},
+	"Create": {"c.Create(ctx, object)", "CreateAll creates all the resources of the generated go-code, It fails for the resources which already exist (Use ApplyAll in a reconcile-loop)"},
+	"Delete": {"client.IgnoreNotFound(c.Delete(ctx, object))", "DeleteAll deletes all the resources of the generated go-code, The resources which are not found are ignored"},
+}
+
+/*
+A resource of the generated go-code (The index-th resource returned by Get<resourceType>()) along with its install-weight
+*/
+type installOrderEntry struct {
+	resourceType string
+	index        int
+	weight       int
+}
+
 type GoFile struct {
 	Namespace             string
-	FileContent           string
-	runtimeSupportKindSet set.Set[string] // To be Set By Intialise
+	PackageName           string            // Package of the Generated Go-File(s), Defaults to controller
+	OutputDir             string            // Directory of the Generated Go-File(s), Defaults to outputs
+	FileName              string            // Name of the Generated Go-File, Defaults to generated_code.go
+	SplitByKind           bool              // If true, Get<Kind>() of each kind is written to <kind>.go, and the helper fxns & ApplyAll/CreateAll/DeleteAll to FileName
+	FieldManager          string            // Field-Manager of the server-side apply (ApplyAll), Defaults to helm-to-operator-codegen-sdk
+	InstallWeights        map[string][]int  // Resource-Type as Key and the install-weight of each of its resources as Value (Same order as of gocodes), Weight 0 if not provided
+	TypeDefinitions       map[string]string // Resource-Type as Key and the go-code of its type-definitions as Value (Typed go-structs of Custom-Resources), Written along with Get<Kind>()
+	ResourceKinds         map[string]string // Resource-Type as Key and its Kind as Value, If they differ (Example: HorizontalPodAutoscalerV2 --> HorizontalPodAutoscaler), Used for the install-order
+	ValuesTypes           string            // Go-Code of the kubebuilder API-Type whose Spec mirrors the values of the helm-chart (See ValuesStructConverter), Written to values_types.go
+	Imports               map[string]string // Alias as Key and Import-Path as Value, Imports other than goFileImports (Go-Packages of the Go-Types of Third-Party Kinds)
+	FileContent           string            // Content of FileName (Set By Generate)
+	Files                 map[string]string // File-Name as Key and its Content as Value, Contains FileName & <kind>.go files (Set By Generate)
+	runtimeSupportKindSet set.Set[string]   // To be Set By Intialise
 }
 
 /*
@@ -94,61 +143,136 @@ func Get%s() []%s{
 }
 
 /*
-It adds the imports as well as the helper fxns like int_ptr, string_ptr
-Input:
-
-	allFxn: Go-code for all the fxns (Get_Service(), Get_Deployment()) concatenated in a single string
-	fxnCreated: List of all the fxnNames that allFxn contains (Used in getMasterFxn)
-	debugging: For Testing (to be removed)
-
-Output:
+Imports which can be used by the generated go-code, An import is added to the Go-File only if its alias is used
+*/
+var goFileImports = []struct {
+	alias string
+	path  string
+}{
+	{"context", "context"},
+	{"errors", "errors"},
+	{"fmt", "fmt"},
+	{"time", "time"},
+	{"base64", "encoding/base64"},
+	{"json", "encoding/json"},
+	{"admissionregistrationv1", "k8s.io/api/admissionregistration/v1"},
+	{"admissionregistrationv1alpha1", "k8s.io/api/admissionregistration/v1alpha1"},
+	{"admissionregistrationv1beta1", "k8s.io/api/admissionregistration/v1beta1"},
+	{"apiextensionsv1", "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"},
+	{"apiserverinternalv1alpha1", "k8s.io/api/apiserverinternal/v1alpha1"},
+	{"appsv1", "k8s.io/api/apps/v1"},
+	{"appsv1beta1", "k8s.io/api/apps/v1beta1"},
+	{"appsv1beta2", "k8s.io/api/apps/v1beta2"},
+	{"authenticationv1", "k8s.io/api/authentication/v1"},
+	{"authenticationv1alpha1", "k8s.io/api/authentication/v1alpha1"},
+	{"authenticationv1beta1", "k8s.io/api/authentication/v1beta1"},
+	{"authorizationv1", "k8s.io/api/authorization/v1"},
+	{"authorizationv1beta1", "k8s.io/api/authorization/v1beta1"},
+	{"autoscalingv1", "k8s.io/api/autoscaling/v1"},
+	{"autoscalingv2", "k8s.io/api/autoscaling/v2"},
+	{"autoscalingv2beta1", "k8s.io/api/autoscaling/v2beta1"},
+	{"autoscalingv2beta2", "k8s.io/api/autoscaling/v2beta2"},
+	{"batchv1", "k8s.io/api/batch/v1"},
+	{"batchv1beta1", "k8s.io/api/batch/v1beta1"},
+	{"certificatesv1", "k8s.io/api/certificates/v1"},
+	{"certificatesv1alpha1", "k8s.io/api/certificates/v1alpha1"},
+	{"certificatesv1beta1", "k8s.io/api/certificates/v1beta1"},
+	{"coordinationv1", "k8s.io/api/coordination/v1"},
+	{"coordinationv1beta1", "k8s.io/api/coordination/v1beta1"},
+	{"corev1", "k8s.io/api/core/v1"},
+	{"discoveryv1", "k8s.io/api/discovery/v1"},
+	{"discoveryv1beta1", "k8s.io/api/discovery/v1beta1"},
+	{"eventsv1", "k8s.io/api/events/v1"},
+	{"eventsv1beta1", "k8s.io/api/events/v1beta1"},
+	{"extensionsv1beta1", "k8s.io/api/extensions/v1beta1"},
+	{"flowcontrolv1alpha1", "k8s.io/api/flowcontrol/v1alpha1"},
+	{"flowcontrolv1beta1", "k8s.io/api/flowcontrol/v1beta1"},
+	{"flowcontrolv1beta2", "k8s.io/api/flowcontrol/v1beta2"},
+	{"flowcontrolv1beta3", "k8s.io/api/flowcontrol/v1beta3"},
+	{"metav1", "k8s.io/apimachinery/pkg/apis/meta/v1"},
+	{"networkingv1", "k8s.io/api/networking/v1"},
+	{"networkingv1alpha1", "k8s.io/api/networking/v1alpha1"},
+	{"networkingv1beta1", "k8s.io/api/networking/v1beta1"},
+	{"nodev1", "k8s.io/api/node/v1"},
+	{"nodev1alpha1", "k8s.io/api/node/v1alpha1"},
+	{"nodev1beta1", "k8s.io/api/node/v1beta1"},
+	{"policyv1", "k8s.io/api/policy/v1"},
+	{"policyv1beta1", "k8s.io/api/policy/v1beta1"},
+	{"rbacv1", "k8s.io/api/rbac/v1"},
+	{"rbacv1alpha1", "k8s.io/api/rbac/v1alpha1"},
+	{"rbacv1beta1", "k8s.io/api/rbac/v1beta1"},
+	{"resourcev1alpha2", "k8s.io/api/resource/v1alpha2"},
+	{"schedulingv1", "k8s.io/api/scheduling/v1"},
+	{"schedulingv1alpha1", "k8s.io/api/scheduling/v1alpha1"},
+	{"schedulingv1beta1", "k8s.io/api/scheduling/v1beta1"},
+	{"storagev1", "k8s.io/api/storage/v1"},
+	{"storagev1alpha1", "k8s.io/api/storage/v1alpha1"},
+	{"storagev1beta1", "k8s.io/api/storage/v1beta1"},
+	{"intstr", "k8s.io/apimachinery/pkg/util/intstr"},
+	{"resource", "k8s.io/apimachinery/pkg/api/resource"},
+	{"runtime", "k8s.io/apimachinery/pkg/runtime"},
+	{"schema", "k8s.io/apimachinery/pkg/runtime/schema"},
+	{"unstructured", "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"},
+	{"ptr", "k8s.io/utils/ptr"},
+	{"client", "sigs.k8s.io/controller-runtime/pkg/client"},
+	{"controllerutil", "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"},
+}
 
-	A Go Package, containing all the functions, helper functions, required imports, The output of this function is what you see in the generated_code.go
+/*
+Returns the aliases (Identifiers followed by a dot, Example: corev1 in corev1.Service{}) used in the go-code,
+Comments & string-literals are ignored (Therefore a alias mentioned only in a comment doesn't require any import)
 */
-func (obj *GoFile) addFunctionsToGofile(allFxn string, fxnCreated []string, debugging bool) string {
-	packageName := "main"
-	if !debugging {
-		packageName = "controller"
+func getUsedAliases(gocode string) map[string]bool {
+	usedAliases := map[string]bool{}
+	fileSet := token.NewFileSet()
+	var goScanner scanner.Scanner
+	goScanner.Init(fileSet.AddFile("", fileSet.Base(), len(gocode)), []byte(gocode), nil, 0)
+	prevTok, prevLit := token.ILLEGAL, ""
+	for {
+		_, tok, lit := goScanner.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.PERIOD && prevTok == token.IDENT {
+			usedAliases[prevLit] = true
+		}
+		prevTok, prevLit = tok, lit
 	}
-	fileText := fmt.Sprintf(`
-package %s
-
-import (
-	"context"
-	"fmt"
-	"time"
-	"encoding/base64"
-	
-	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
-	schedulingv1 "k8s.io/api/scheduling/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/api/resource"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/utils/ptr"
-)
+	return usedAliases
+}
 
-func deleteMeAfterDeletingUnusedImportedModules() {
-	/*
-		It is written to handle the error "Module Imported but not used",
-		The user can delete the non-required modules from import and then delete this function also
-	*/
-	_ = time.Now()
-	_ = &unstructured.Unstructured{}
-	_ = corev1.Service{}
-	_ = metav1.ObjectMeta{}
-	_ = appsv1.Deployment{}
-	_ = rbacv1.Role{}
-	_ = schedulingv1.PriorityClass{}
-	_ = intstr.FromInt(4)
-	_, _ = resource.ParseQuantity("")
-	_ = context.TODO()
-	_ = fmt.Sprintf("")
-	_ = ptr.To(32)
+/*
+Returns the Go-File containing the package-clause & the imports (only those which are used by the go-code), followed by the go-code
+*/
+func (obj *GoFile) getGoFileContent(packageName string, gocode string) string {
+	usedAliases := getUsedAliases(gocode)
+	imports := ""
+	for _, goImport := range goFileImports {
+		if !usedAliases[goImport.alias] {
+			continue
+		}
+		if goImport.alias == filepath.Base(goImport.path) {
+			imports += fmt.Sprintf("\t%q\n", goImport.path)
+		} else {
+			imports += fmt.Sprintf("\t%s %q\n", goImport.alias, goImport.path)
+		}
+	}
+	for _, alias := range sortedKeys(obj.Imports) {
+		if usedAliases[alias] {
+			imports += fmt.Sprintf("\t%s %q\n", alias, obj.Imports[alias])
+		}
+	}
+	if imports != "" {
+		imports = fmt.Sprintf("\nimport (\n%s)\n", imports)
+	}
+	return fmt.Sprintf("\npackage %s\n%s", packageName, imports) + gocode
 }
 
+/*
+Returns the helper fxns like int32Ptr, stringPtr, getDataForSecret used by the go-code of the resources
+*/
+func (obj *GoFile) getHelperFxns() string {
+	return `
 func int32Ptr(val int) *int32 {
 	var a int32
 	a = int32(val)
@@ -187,102 +311,282 @@ func getDataForSecret(encodedVal string) []byte {
 		Concept: Based on my Understanding, corev1.Secret requires the actual data(not encoded) as secret-Data
 		But in general terms, we put encoded values in secret-data, which make sense (why to write actual value in readable format)
 		This function takes the encodedVal and decodes it and returns
+		The encodedVal is validated while generating the go-code, Therefore It panics on an invalid value (Same as resource.MustParse)
 	*/
 	decodeVal, err := base64.StdEncoding.DecodeString(encodedVal)
 	if err != nil {
-		fmt.Println("Unable to decode the SecretVal ", encodedVal, " || This Secret Will Probably would give error during deployment| Kindly Check")
-		return []byte(encodedVal)
+		panic(fmt.Errorf("unable to decode the secret-data %q| %w", encodedVal, err))
 	}
 	return decodeVal
 }
 
-`, packageName) + obj.getMasterFxn(fxnCreated, true) + obj.getMasterFxn(fxnCreated, false) + allFxn
-	mainfxn := `
-	func main(){
-		fmt.Println("Only for Debbugging purpose")
-		fmt.Println(GetService())
-		fmt.Println(GetDeployment())
-	}
-	`
-	if debugging {
-		return fileText + mainfxn
-	} else {
-		return fileText
-	}
-
+`
 }
 
 /*
+It adds the imports as well as the helper fxns like int_ptr, string_ptr
 Input:
 
-	fxnCreated: List all functions that has been created so far, Example: [Get_Service(), Get_Deployment()]
-	inCreatedState: Bool: true for Create_All, and false for Delete_All
+	allFxn: Go-code for all the fxns (Get_Service(), Get_Deployment()) concatenated in a single string
+	installOrder: All the resources of allFxn in the install-order (See getInstallOrder)
 
 Output:
 
-	Output the Go-Code of the function that can either create or delete all the resources
+	A Go Package, containing all the functions, helper functions, required imports, The output of this function is what you see in the generated_code.go
 */
-func (obj *GoFile) getMasterFxn(fxnCreated []string, inCreatedState bool) string {
-	usage := "Delete"
-	if inCreatedState {
-		usage = "Create"
-	}
-	fxnStatement := ""
-	if obj.Namespace == "" {
-		for _, fxnName := range fxnCreated {
-			fxnStatement += fmt.Sprintf(`
-	for _, resource := range %s{
-		err = r.%s(context.TODO(), resource)
-		if err != nil {
-			fmt.Println("Erorr During %sing resource of %s| Error --> |", err)
-		}
-	} 
-			`, fxnName, usage, usage[:len(usage)-1], fxnName)
+func (obj *GoFile) addFunctionsToGofile(allFxn string, installOrder []installOrderEntry) string {
+	fileText := obj.getHelperFxns() + obj.getFieldManagerConst() + obj.getObjectsFxn(installOrder) + obj.getMasterFxn(installOrder, "Apply", false) +
+		obj.getMasterFxn(installOrder, "Create", false) + obj.getMasterFxn(installOrder, "Delete", false) + obj.getOwnerFxns() +
+		obj.getMasterFxn(installOrder, "Apply", true) + obj.getMasterFxn(installOrder, "Create", true) + allFxn
+	return obj.getGoFileContent(obj.getPackageName(), fileText)
+}
+
+/*
+Returns the go-code of the FieldManager const, used by the server-side apply of ApplyAll
+*/
+func (obj *GoFile) getFieldManagerConst() string {
+	fieldManager := obj.FieldManager
+	if fieldManager == "" {
+		fieldManager = defaultFieldManager
+	}
+	return fmt.Sprintf(`
+// FieldManager is the field-manager (owner of the fields) of the server-side apply of ApplyAll
+const FieldManager = %q
+`, fieldManager)
+}
+
+/*
+Returns the rank of the kind in the install-order of helm (releaseutil.InstallOrder, Example: Namespace, ServiceAccount, Secret, ConfigMap, ..., Service, Deployment, ...)
+The kinds unknown to helm (Example: Custom-Resources) are installed at last
+*/
+func getKindInstallRank(kind string) int {
+	for rank, installKind := range releaseutil.InstallOrder {
+		if installKind == kind {
+			return rank
 		}
-	} else {
-		for _, fxnName := range fxnCreated {
-			fxnResourceType := fxnName[3 : len(fxnName)-2] // ResourceType from GetService(), GetDeployment()
-			ifblock :=
-				`if resource.ObjectMeta.Namespace == ""{
-			resource.ObjectMeta.Namespace = namespaceProvided
-		}`
-			if !obj.runtimeSupportKindSet.Contains(fxnResourceType) {
-				// If the resource type is unstructured.Unstructured
-				ifblock =
-					`if resource.GetNamespace() == ""{
-			resource.SetNamespace(namespaceProvided)
-		}`
+	}
+	return len(releaseutil.InstallOrder)
+}
+
+/*
+Returns the Kind of the Resource-Type (ResourceKinds), The Resource-Type itself if it is not in ResourceKinds
+*/
+func (obj *GoFile) getResourceKind(resourceType string) string {
+	if kind, ok := obj.ResourceKinds[resourceType]; ok {
+		return kind
+	}
+	return resourceType
+}
+
+/*
+Returns all the resources of the gocodes in the install-order: Sorted by the install-weight (InstallWeights), then by the install-order of their kind (Same as of helm),
+The kinds unknown to helm are sorted by their name, and the resources of a kind remain in the order of gocodes
+*/
+func (obj *GoFile) getInstallOrder(gocodes map[string][]string) []installOrderEntry {
+	var installOrder []installOrderEntry
+	for _, resourceType := range sortedKeys(gocodes) {
+		for i := range gocodes[resourceType] {
+			entry := installOrderEntry{resourceType: resourceType, index: i}
+			if weights := obj.InstallWeights[resourceType]; i < len(weights) {
+				entry.weight = weights[i]
 			}
+			installOrder = append(installOrder, entry)
+		}
+	}
+	sort.SliceStable(installOrder, func(i, j int) bool {
+		if installOrder[i].weight != installOrder[j].weight {
+			return installOrder[i].weight < installOrder[j].weight
+		}
+		return getKindInstallRank(obj.getResourceKind(installOrder[i].resourceType)) < getKindInstallRank(obj.getResourceKind(installOrder[j].resourceType))
+	})
+	return installOrder
+}
 
-			fxnStatement += fmt.Sprintf(`
-	for _, resource := range %s{
-		%s
-		err = r.%s(context.TODO(), resource)
-		if err != nil {
-			fmt.Println("Erorr During %sing resource of %s| Error --> |", err)
+/*
+Returns the variable-name of the resources of the Resource-Type, Example: Service --> serviceList
+*/
+func getResourceListVarName(resourceType string) string {
+	return strings.ToLower(resourceType[:1]) + resourceType[1:] + "List"
+}
+
+/*
+Output: Go-Code of getObjects(), which returns all the resources in the install-order (Used by ApplyAll/CreateAll, and in the reverse-order by DeleteAll)
+Example:
+
+	func getObjects() []client.Object {
+		serviceAccountList := GetServiceAccount()
+		deploymentList := GetDeployment()
+		return []client.Object{
+			serviceAccountList[0],
+			deploymentList[0],
 		}
-	} 
-			`, fxnName, ifblock, usage, usage[:len(usage)-1], fxnName)
+	}
+*/
+func (obj *GoFile) getObjectsFxn(installOrder []installOrderEntry) string {
+	varStatements, objects := "", ""
+	declaredVars := map[string]bool{}
+	for _, entry := range installOrder {
+		varName := getResourceListVarName(entry.resourceType)
+		if !declaredVars[varName] {
+			declaredVars[varName] = true
+			varStatements += fmt.Sprintf("\t%s := Get%s()\n", varName, entry.resourceType)
 		}
+		objects += fmt.Sprintf("\t\t%s[%d],\n", varName, entry.index)
 	}
+	return fmt.Sprintf(`
+/*
+getObjects returns all the resources of the generated go-code in the install-order (Same as of helm: Namespace, ServiceAccount, Secret, ConfigMap, ..., Service, Deployment, ...)
+The order can be overridden by the install-weight annotation of the resources
+*/
+func getObjects() []client.Object {
+%s	return []client.Object{
+%s	}
+}
+`, varStatements, objects)
+}
+
+/*
+Input:
 
-	namespaceVarStatement := ""
-	if obj.Namespace != "" {
-		namespaceVarStatement = fmt.Sprintf("namespaceProvided := \"%s\"", obj.Namespace)
+	installOrder: All the resources in the install-order (See getInstallOrder)
+	usage: Apply (server-side apply), Create or Delete (See masterFxns)
+	withOwner: If true, <usage>AllWithOwner is generated, which sets the owner of every resource before applying/creating it (See getOwnerFxns),
+		Not to be used with Delete (The owned resources are deleted by the garbage-collector of kubernetes)
+
+Output:
+
+	Output the Go-Code of the function that can either apply, create or delete all the resources, using the client of controller-runtime
+	The resources are applied/created in the install-order (getObjects) and deleted in the reverse-order
+	The resources without namespace are created in the Namespace (If provided), The errors of all the resources are aggregated (errors.Join)
+	ApplyAll & DeleteAll are idempotent (The resources are patched using server-side apply, Resources which are not found are not deleted)
+
+Example:
+
+	func CreateAll(ctx context.Context, c client.Client) error {
+		var errs []error
+		objects := getObjects()
+		for i := range objects {
+			object := objects[i]
+			if err := c.Create(ctx, object); err != nil {
+				errs = append(errs, fmt.Errorf("unable to create %s %s/%s| %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetNamespace(), object.GetName(), err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+*/
+func (obj *GoFile) getMasterFxn(installOrder []installOrderEntry, usage string, withOwner bool) string {
+	namespaceStatement, ifblock := "", ""
+	if obj.Namespace != "" && len(installOrder) != 0 {
+		namespaceStatement = fmt.Sprintf("\tnamespaceProvided := %q\n", obj.Namespace)
+		ifblock = `
+		if object.GetNamespace() == "" {
+			object.SetNamespace(namespaceProvided)
+		}`
+	}
+	objectIndex := "i"
+	if usage == "Delete" {
+		objectIndex = "len(objects) - 1 - i" // Reverse of the install-order
 	}
+	fxnName, fxnDoc, ownerParams, ownerBlock := usage+"All", masterFxns[usage].doc, "", ""
+	if withOwner {
+		fxnName += "WithOwner"
+		fxnDoc = fmt.Sprintf("%s is same as %sAll, But the owner is set as the controller-owner of the namespaced resources (Deleted along with the owner by the garbage-collector),\n"+
+			"The cluster-scoped resources are labelled with OwnerLabel instead, if the owner is namespaced (Deleted by CleanupClusterScoped)\n"+
+			"scheme is the scheme containing the type of the owner (Example: mgr.GetScheme())", fxnName, usage)
+		ownerParams = ", owner metav1.Object, scheme *runtime.Scheme"
+		ownerBlock = `
+		if err := setOwner(c, object, owner, scheme); err != nil {
+			errs = append(errs, fmt.Errorf("unable to set the owner of %s %s/%s| %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetNamespace(), object.GetName(), err))
+			continue
+		}`
+	}
+
 	outFxn := fmt.Sprintf(`
 /*
-// Before Uncommenting the following function, Make sure the data-type of r is same as of your Reconciler,
-// Replace "YourKindReconciler" with the type of your Reconciler
-func (r *YourKindReconciler)%sAll(){
- 	var err error
-	%s
-	%s
+%s
+c is the client of controller-runtime (Example: mgr.GetClient()), The errors of all the resources are returned together
+*/
+func %s(ctx context.Context, c client.Client%s) error {
+%s	var errs []error
+	objects := getObjects()
+	for i := range objects {
+		object := objects[%s]%s%s
+		if err := %s; err != nil {
+			errs = append(errs, fmt.Errorf("unable to %s %%s %%s/%%s| %%w", object.GetObjectKind().GroupVersionKind().Kind, object.GetNamespace(), object.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
 }
+`, fxnDoc, fxnName, ownerParams, namespaceStatement, objectIndex, ifblock, ownerBlock, masterFxns[usage].call, strings.ToLower(usage))
+	return outFxn
+}
+
+/*
+Returns the go-code of the OwnerLabel const and the fxns used to own the resources by a custom-resource (Example: The custom-resource reconciled by the operator):
+
+	setOwner: Sets the owner as the controller-owner (controllerutil.SetControllerReference) of a namespaced resource (Or of any resource, if the owner is cluster-scoped),
+		The cluster-scoped resources can't be owned by a namespaced owner, Therefore they are labelled with OwnerLabel (Value: UID of the owner)
+	CleanupClusterScoped: Deletes the cluster-scoped resources labelled with the OwnerLabel of the owner (Fallback of the garbage-collection, To be called by the finalizer of the owner)
+
+The scope of a resource is found using the RESTMapper of the client (client.IsObjectNamespaced), Therefore it works for the Custom-Resources as well
 */
+func (obj *GoFile) getOwnerFxns() string {
+	return fmt.Sprintf(`
+// OwnerLabel is set on the cluster-scoped resources owned by a namespaced owner (See ApplyAllWithOwner, CreateAllWithOwner), Its value is the UID of the owner
+const OwnerLabel = %q
 
-	`, usage, namespaceVarStatement, fxnStatement)
-	return outFxn
+/*
+setOwner sets the owner as the controller-owner of the object, If the object is namespaced or the owner is cluster-scoped
+Otherwise (A cluster-scoped object can't be owned by a namespaced owner), the object is labelled with OwnerLabel (Deleted by CleanupClusterScoped)
+*/
+func setOwner(c client.Client, object client.Object, owner metav1.Object, scheme *runtime.Scheme) error {
+	namespaced, err := c.IsObjectNamespaced(object)
+	if err != nil {
+		return err
+	}
+	if namespaced || owner.GetNamespace() == "" {
+		return controllerutil.SetControllerReference(owner, object, scheme)
+	}
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[OwnerLabel] = string(owner.GetUID())
+	object.SetLabels(labels)
+	return nil
+}
+
+/*
+CleanupClusterScoped deletes the cluster-scoped resources labelled with the OwnerLabel of the owner (Of all the kinds of the generated go-code),
+These aren't deleted by the garbage-collector along with a namespaced owner, Therefore it should be called by the finalizer of the owner
+The namespaced resources are deleted by the garbage-collector (See ApplyAllWithOwner, CreateAllWithOwner)
+*/
+func CleanupClusterScoped(ctx context.Context, c client.Client, owner metav1.Object) error {
+	var errs []error
+	cleanedKinds := map[schema.GroupVersionKind]bool{}
+	objects := getObjects()
+	for i := range objects {
+		object := objects[len(objects)-1-i]
+		gvk := object.GetObjectKind().GroupVersionKind()
+		if cleanedKinds[gvk] {
+			continue
+		}
+		cleanedKinds[gvk] = true
+		namespaced, err := c.IsObjectNamespaced(object)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to find the scope of %%s| %%w", gvk.Kind, err))
+			continue
+		}
+		if namespaced {
+			continue
+		}
+		if err := c.DeleteAllOf(ctx, object, client.MatchingLabels{OwnerLabel: string(owner.GetUID())}); err != nil {
+			errs = append(errs, fmt.Errorf("unable to cleanup %%s| %%w", gvk.Kind, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+`, ownerLabel)
 }
 
 func (obj *GoFile) Intialise(runtimeSupportKinds []string) {
@@ -293,6 +597,34 @@ func (obj *GoFile) Intialise(runtimeSupportKinds []string) {
 	obj.runtimeSupportKindSet = *tempSet
 }
 
+func (obj *GoFile) getPackageName() string {
+	if obj.PackageName == "" {
+		return defaultGoFilePackageName
+	}
+	return obj.PackageName
+}
+
+/*
+Returns the name of the Generated Go-File (FileName, Defaults to generated_code.go)
+*/
+func (obj *GoFile) GetFileName() string {
+	if obj.FileName == "" {
+		return defaultGoFileName
+	}
+	return obj.FileName
+}
+
+/*
+Returns the path of the Generated Go-File (In the OutputDir, Defaults to outputs)
+Example: GetOutputPath("service.go") --> outputs/service.go
+*/
+func (obj *GoFile) GetOutputPath(fileName string) string {
+	if obj.OutputDir == "" {
+		return filepath.Join(defaultGoFileOutputDir, fileName)
+	}
+	return filepath.Join(obj.OutputDir, fileName)
+}
+
 /*
 Input: Map of Resource-Type as Key and the Value represents Go-Codes corresponding to the resource-type in slice
 Example: "Service": ["GO-Code for Service-1", "GO-Code for Service-2"]
@@ -302,25 +634,54 @@ Example: "Service": ["GO-Code for Service-1", "GO-Code for Service-2"]
 Output:
 
 	Generates the Go-file String Content containing all the functions and libray imports, so the gocode can be deployed/ pluged in
+	If SplitByKind, Get<Kind>() of each kind is generated in a separate file (Example: Service --> service.go)
+	If ValuesTypes is provided, it is generated in values_types.go
+	The Go-File(s) are formatted using go/format (gofmt), Returns an error if the content of any Go-File is not a valid go-code
 */
-func (obj *GoFile) Generate(gocodes map[string][]string) {
+func (obj *GoFile) Generate(gocodes map[string][]string) error {
+	obj.Files = map[string]string{}
 	allFxn := ""
-	functionsCreated := []string{}
-	for resourceType, resourceList := range gocodes {
-		allFxn += obj.getRunnableFunction(resourceType, resourceList)
-		functionsCreated = append(functionsCreated, fmt.Sprintf("Get%s()", resourceType))
+	for _, resourceType := range sortedKeys(gocodes) {
+		fxn := obj.TypeDefinitions[resourceType] + obj.getRunnableFunction(resourceType, gocodes[resourceType])
+		if obj.SplitByKind {
+			obj.Files[strings.ToLower(resourceType)+".go"] = obj.getGoFileContent(obj.getPackageName(), fxn)
+		} else {
+			allFxn += fxn
+		}
 	}
-	fileText := obj.addFunctionsToGofile(allFxn, functionsCreated, false)
-	obj.FileContent = fileText
+	obj.Files[obj.GetFileName()] = obj.addFunctionsToGofile(allFxn, obj.getInstallOrder(gocodes))
+	if obj.ValuesTypes != "" {
+		obj.Files[valuesTypesFileName] = obj.getGoFileContent(obj.getPackageName(), obj.ValuesTypes)
+	}
+	for _, fileName := range sortedKeys(obj.Files) {
+		formattedContent, err := format.Source([]byte(obj.Files[fileName]))
+		if err != nil {
+			logrus.Error("Formatting the Go-File ", fileName, " FAILED| Error --> | ", err)
+			return fmt.Errorf("unable to format the go-file %s| %w", fileName, err)
+		}
+		obj.Files[fileName] = string(formattedContent)
+	}
+	obj.FileContent = obj.Files[obj.GetFileName()]
+	return nil
 }
 
 /*
-Writes the "output_gocode/generated_code.go" file
+Writes the Generated Go-File(s) to the OutputDir (Defaults to outputs/generated_code.go)
 */
-func (obj *GoFile) WriteToFile() {
-	_ = createDirIfDontExist("outputs")
-	err := os.WriteFile("outputs/generated_code.go", []byte(obj.FileContent), 0600)
-	if err != nil {
-		logrus.Fatal("Writing gocode to outputs/generated_code.go FAILED| Error --> | ", err)
+func (obj *GoFile) WriteToFile() error {
+	if obj.Files == nil {
+		obj.Files = map[string]string{obj.GetFileName(): obj.FileContent}
+	}
+	for _, fileName := range sortedKeys(obj.Files) {
+		outputPath := obj.GetOutputPath(fileName)
+		if err := createDirIfDontExist(filepath.Dir(outputPath)); err != nil {
+			return err
+		}
+		err := os.WriteFile(outputPath, []byte(obj.Files[fileName]), 0600)
+		if err != nil {
+			logrus.Error("Writing gocode to ", outputPath, " FAILED| Error --> | ", err)
+			return err
+		}
 	}
+	return nil
 }
@@ -17,7 +17,10 @@ limitations under the License.
 package common
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -53,87 +56,157 @@ func TestGetRunnableFunction(t *testing.T) {
 }
 
 func TestGetMasterFxnCreateAll(t *testing.T) {
-	result := goFileObj.getMasterFxn([]string{"GetDeployment"}, true)
+	result := goFileObj.getMasterFxn([]installOrderEntry{{resourceType: "Deployment"}}, "Create", false)
 	expectedContent := `
-/*
-// Before Uncommenting the following function, Make sure the data-type of r is same as of your Reconciler,
-// Replace "YourKindReconciler" with the type of your Reconciler
-func (r *YourKindReconciler)CreateAll(){
-	var err error
+func CreateAll(ctx context.Context, c client.Client) error {
 	namespaceProvided := "default"
-
-	for _, resource := range GetDeployment{
-		if resource.GetNamespace() == ""{
-			resource.SetNamespace(namespaceProvided)
+	var errs []error
+	objects := getObjects()
+	for i := range objects {
+		object := objects[i]
+		if object.GetNamespace() == "" {
+			object.SetNamespace(namespaceProvided)
 		}
-		err = r.Create(context.TODO(), resource)
-		if err != nil {
-			fmt.Println("Erorr During Creating resource of GetDeployment| Error --> |", err)
+		if err := c.Create(ctx, object); err != nil {
+			errs = append(errs, fmt.Errorf("unable to create %s %s/%s| %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetNamespace(), object.GetName(), err))
 		}
 	}
-
+	return errors.Join(errs...)
 }
-*/
 `
-	expectedLines := strings.Split(expectedContent, "\n")
-	for _, expected := range expectedLines {
-		if !strings.Contains(result, expected) {
-			t.Errorf("Current Line '%s' Not Found in Runnable Function| Actual Output : %s \n", expected, result)
-			break
-		}
+	if !strings.Contains(result, expectedContent) {
+		t.Errorf("CreateAll is not as expected| Expected : %s \nActual Output : %s \n", expectedContent, result)
 	}
 }
 
 func TestGetMasterFxnDeleteAll(t *testing.T) {
-	result := goFileObj.getMasterFxn([]string{"GetDeployment"}, false)
+	// Without Namespace, The namespace of the resources is not changed
+	goFileObj := GoFile{}
+	result := goFileObj.getMasterFxn([]installOrderEntry{{resourceType: "Deployment"}}, "Delete", false)
 	expectedContent := `
-/*
-// Before Uncommenting the following function, Make sure the data-type of r is same as of your Reconciler,
-// Replace "YourKindReconciler" with the type of your Reconciler
-func (r *YourKindReconciler)DeleteAll(){
-	var err error
-	namespaceProvided := "default"
-
-	for _, resource := range GetDeployment{
-		if resource.GetNamespace() == ""{
-			resource.SetNamespace(namespaceProvided)
-		}
-		err = r.Delete(context.TODO(), resource)
-		if err != nil {
-			fmt.Println("Erorr During Deleting resource of GetDeployment| Error --> |", err)
+func DeleteAll(ctx context.Context, c client.Client) error {
+	var errs []error
+	objects := getObjects()
+	for i := range objects {
+		object := objects[len(objects) - 1 - i]
+		if err := client.IgnoreNotFound(c.Delete(ctx, object)); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete %s %s/%s| %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetNamespace(), object.GetName(), err))
 		}
 	}
-
+	return errors.Join(errs...)
 }
-*/
 `
-	expectedLines := strings.Split(expectedContent, "\n")
-	for _, expected := range expectedLines {
+	if !strings.Contains(result, expectedContent) {
+		t.Errorf("DeleteAll is not as expected| Expected : %s \nActual Output : %s \n", expectedContent, result)
+	}
+	// Without Resources, namespaceProvided is not declared (It would be unused)
+	if result := (&GoFile{Namespace: "default"}).getMasterFxn(nil, "Delete", false); strings.Contains(result, "namespaceProvided") {
+		t.Errorf("namespaceProvided should not be declared without resources| Actual Output : %s \n", result)
+	}
+}
+
+func TestGetMasterFxnApplyAll(t *testing.T) {
+	goFileObj := GoFile{FieldManager: "amf-operator"}
+	result := goFileObj.getFieldManagerConst() + goFileObj.getMasterFxn([]installOrderEntry{{resourceType: "Deployment"}}, "Apply", false)
+	for _, expected := range []string{`const FieldManager = "amf-operator"`, "func ApplyAll(ctx context.Context, c client.Client) error {",
+		"if err := c.Patch(ctx, object, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {"} {
 		if !strings.Contains(result, expected) {
-			t.Errorf("Current Line '%s' Not Found in Runnable Function| Actual Output : %s \n", expected, result)
-			break
+			t.Errorf("'%s' Not Found in ApplyAll| Actual Output : %s \n", expected, result)
 		}
 	}
+	if result := (&GoFile{}).getFieldManagerConst(); !strings.Contains(result, `const FieldManager = "helm-to-operator-codegen-sdk"`) {
+		t.Errorf("Default Field-Manager is not as expected| Actual Output : %s \n", result)
+	}
+}
+
+func TestGetMasterFxnWithOwner(t *testing.T) {
+	goFileObj := GoFile{Namespace: "default"}
+	result := goFileObj.getOwnerFxns() + goFileObj.getMasterFxn([]installOrderEntry{{resourceType: "Deployment"}}, "Create", true)
+	for _, expected := range []string{`const OwnerLabel = "nephio.org/owner-uid"`,
+		"func CreateAllWithOwner(ctx context.Context, c client.Client, owner metav1.Object, scheme *runtime.Scheme) error {",
+		"if err := setOwner(c, object, owner, scheme); err != nil {",
+		"return controllerutil.SetControllerReference(owner, object, scheme)",
+		"func CleanupClusterScoped(ctx context.Context, c client.Client, owner metav1.Object) error {",
+		"c.DeleteAllOf(ctx, object, client.MatchingLabels{OwnerLabel: string(owner.GetUID())})"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("'%s' Not Found in CreateAllWithOwner| Actual Output : %s \n", expected, result)
+		}
+	}
+	// The namespace is set before the owner (Owner-References can't be cross-namespace)
+	if strings.Index(result, "object.SetNamespace(namespaceProvided)") > strings.Index(result, "setOwner(c, object, owner, scheme); err != nil") {
+		t.Errorf("Namespace should be set before the owner| Actual Output : %s \n", result)
+	}
+}
+
+func TestGetInstallOrder(t *testing.T) {
+	gocodes := map[string][]string{"Deployment": {"d1", "d2"}, "Database": {"db1"}, "ServiceAccount": {"sa1"}, "ConfigMap": {"cm1"}, "Namespace": {"ns1"}}
+	tests := []Tests{
+		{
+			// Same as of helm: Namespace, ServiceAccount, ConfigMap, ..., Deployment, Custom-Resources (Kinds unknown to helm)
+			input:    map[string][]int{},
+			expected: "Namespace[0] ServiceAccount[0] ConfigMap[0] Deployment[0] Deployment[1] Database[0]",
+		},
+		{
+			// Overridden by the install-weights of the resources
+			input:    map[string][]int{"Database": {-1}, "Deployment": {0, 5}},
+			expected: "Database[0] Namespace[0] ServiceAccount[0] ConfigMap[0] Deployment[0] Deployment[1]",
+		},
+		{
+			input:    map[string][]int{"Deployment": {1, 0}},
+			expected: "Namespace[0] ServiceAccount[0] ConfigMap[0] Deployment[1] Database[0] Deployment[0]",
+		},
+	}
+	for _, test := range tests {
+		goFileObj := GoFile{InstallWeights: test.input.(map[string][]int)}
+		var result []string
+		for _, entry := range goFileObj.getInstallOrder(gocodes) {
+			result = append(result, fmt.Sprintf("%s[%d]", entry.resourceType, entry.index))
+		}
+		if strings.Join(result, " ") != test.expected.(string) {
+			t.Errorf("Install-Order is not as expected| Expected : %s | Got : %s", test.expected, strings.Join(result, " "))
+		}
+	}
+
+	objectsFxn := (&GoFile{}).getObjectsFxn(goFileObj.getInstallOrder(map[string][]string{"Deployment": {"d1", "d2"}, "ServiceAccount": {"sa1"}}))
+	expectedContent := "\tserviceAccountList := GetServiceAccount()\n\tdeploymentList := GetDeployment()\n\treturn []client.Object{\n\t\tserviceAccountList[0],\n\t\tdeploymentList[0],\n\t\tdeploymentList[1],\n\t}"
+	if !strings.Contains(objectsFxn, expectedContent) {
+		t.Errorf("getObjects is not as expected| Expected : %s \nActual Output : %s \n", expectedContent, objectsFxn)
+	}
 }
 
 func TestGenerate(t *testing.T) {
 	goFileObj.FileContent = ""
 	input := map[string][]string{
-		"Deployment": {"appsv1.Deployment{struct_attributes...}"},
+		"Deployment": {"&appsv1.Deployment{\n\tObjectMeta  : metav1.ObjectMeta{\n\t\tName  : \"amf\", \n\t}, \n\t}"},
+	}
+	if err := goFileObj.Generate(input); err != nil {
+		t.Fatalf("Generate GoCode Failed| Error %v", err)
 	}
-	goFileObj.Generate(input)
 	if goFileObj.FileContent == "" {
 		t.Errorf("Generate GoCode Failed| Unable to Generate Go-File from Go-Code")
 	}
+	if !strings.Contains(goFileObj.FileContent, "\t\tName: \"amf\",\n") {
+		t.Errorf("Generated Go-File is not gofmt-ed| Got %s", goFileObj.FileContent)
+	}
+}
+
+func TestGenerateInvalidGoCode(t *testing.T) {
+	invalidGoFileObj := GoFile{Namespace: "default"}
+	invalidGoFileObj.Intialise([]string{"Deployment"})
+	if err := invalidGoFileObj.Generate(map[string][]string{"Deployment": {"&appsv1.Deployment{struct_attributes...}"}}); err == nil {
+		t.Errorf("Expected error for Invalid Go-Code, Got nil")
+	}
 }
 
 func TestGenerateWithEmptyNamespace(t *testing.T) {
 	goFileObj.FileContent = ""
 	goFileObj.Namespace = ""
 	input := map[string][]string{
-		"Deployment": {"appsv1.Deployment{struct_attributes...}"},
+		"Deployment": {"&appsv1.Deployment{\n\tObjectMeta  : metav1.ObjectMeta{\n\t\tName  : \"amf\", \n\t}, \n\t}"},
+	}
+	if err := goFileObj.Generate(input); err != nil {
+		t.Fatalf("Generate GoCode Failed| Error %v", err)
 	}
-	goFileObj.Generate(input)
 	if goFileObj.FileContent == "" {
 		t.Errorf("Generate GoCode Failed| Unable to Generate Go-File from Go-Code")
 	}
@@ -145,3 +218,57 @@ func TestWriteToFile(t *testing.T) {
 	}
 	os.RemoveAll("outputs")
 }
+
+func TestGetUsedAliases(t *testing.T) {
+	gocode := `
+/*
+	_ = context.TODO()
+*/
+// fmt.Println("commented")
+func GetService() []*corev1.Service{
+	service1 := &corev1.Service{
+		ObjectMeta  : metav1.ObjectMeta{
+			Name  : "svc.with.dots", 
+		}, 
+	}
+	return []*corev1.Service{service1, }
+}`
+	usedAliases := getUsedAliases(gocode)
+	expected := map[string]bool{"corev1": true, "metav1": true}
+	if !reflect.DeepEqual(usedAliases, expected) {
+		t.Errorf("Used Aliases Detected Incorrectly | Expected %v | Got %v", expected, usedAliases)
+	}
+}
+
+func TestGenerateSplitByKind(t *testing.T) {
+	splitGoFileObj := GoFile{PackageName: "amf", SplitByKind: true}
+	if err := splitGoFileObj.Generate(map[string][]string{
+		"Deployment": {"&appsv1.Deployment{}"},
+		"Service":    {"&corev1.Service{}"},
+	}); err != nil {
+		t.Fatalf("Generate GoCode Failed| Error %v", err)
+	}
+	expectedFiles := []string{"deployment.go", "generated_code.go", "service.go"}
+	if !reflect.DeepEqual(sortedKeys(splitGoFileObj.Files), expectedFiles) {
+		t.Fatalf("Generated Files are not as expected | Expected %v | Got %v", expectedFiles, sortedKeys(splitGoFileObj.Files))
+	}
+	deploymentFile := splitGoFileObj.Files["deployment.go"]
+	if !strings.Contains(deploymentFile, "package amf") || !strings.Contains(deploymentFile, `appsv1 "k8s.io/api/apps/v1"`) ||
+		strings.Contains(deploymentFile, "corev1") || !strings.Contains(deploymentFile, "func GetDeployment()") {
+		t.Errorf("deployment.go is not as expected | Got %s", deploymentFile)
+	}
+	helperFile := splitGoFileObj.Files["generated_code.go"]
+	if !strings.Contains(helperFile, `"encoding/base64"`) || strings.Contains(helperFile, "appsv1") || strings.Contains(helperFile, "func GetDeployment()") {
+		t.Errorf("generated_code.go is not as expected | Got %s", helperFile)
+	}
+
+	splitGoFileObj.OutputDir = t.TempDir()
+	if err := splitGoFileObj.WriteToFile(); err != nil {
+		t.Fatalf("Unable to write the Go-Files | Error %v", err)
+	}
+	for _, fileName := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(splitGoFileObj.OutputDir, fileName)); err != nil {
+			t.Errorf("%s doesn't exist in the OutputDir", fileName)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta1 is a published Go-Package of a Third-Party Kind (Database), Used by the tests of GoTypeConverter
+package v1beta1
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type Role string
+
+const (
+	RoleRead  Role = "read"
+	RoleWrite Role = "write"
+)
+
+type Database struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseSpec   `json:"spec,omitempty"`
+	Status DatabaseStatus `json:"status,omitempty"`
+}
+
+type DatabaseSpec struct {
+	Enabled       *bool                 `json:"enabled,omitempty"`
+	EngineVersion string                `json:"engine-version,omitempty"`
+	Replicas      *int32                `json:"replicas,omitempty"`
+	Ratio         float64               `json:"ratio,omitempty"`
+	Port          intstr.IntOrString    `json:"port,omitempty"`
+	Storage       *StorageSpec          `json:"storage,omitempty"`
+	Users         []User                `json:"users,omitempty"`
+	Settings      map[string]string     `json:"settings,omitempty"`
+	Extra         *apiextensionsv1.JSON `json:"extra,omitempty"`
+	// Data-Types of the built-in kinds
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	Timeout   *metav1.Duration            `json:"timeout,omitempty"`
+}
+
+type StorageSpec struct {
+	Size      resource.Quantity `json:"size,omitempty"`
+	ClassName *string           `json:"class-name,omitempty"`
+}
+
+type User struct {
+	Name  string `json:"name"`
+	Roles []Role `json:"roles,omitempty"`
+}
+
+type DatabaseStatus struct {
+	Ready bool `json:"ready,omitempty"`
+}
+
+func (in *Database) DeepCopyObject() runtime.Object {
+	out := &Database{}
+	data, _ := json.Marshal(in)
+	_ = json.Unmarshal(data, out)
+	return out
+}
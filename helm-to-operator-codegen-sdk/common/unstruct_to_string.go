@@ -64,12 +64,13 @@ func (obj *UnstructStringConverter) runDfsUnstruct(v reflect.Value, tabs int) st
 	case reflect.Map:
 		out := ""
 		curMap := v.Interface().(map[string]any)
-		for key, val := range curMap {
+		for _, key := range sortedKeys(curMap) {
+			val := curMap[key]
 			// Run DFS over all the Values of Map, and capture the backtrack value
 			backtackValues := obj.runDfsUnstruct(reflect.ValueOf(val), tabs+1)
 
-			logrus.Debugf("%s\"%s\": %s", repeat("\t", tabs), key, backtackValues)
-			out += fmt.Sprintf("%s\"%s\": %s,\n", repeat("\t", tabs), key, backtackValues)
+			logrus.Debugf("%s%q: %s", repeat("\t", tabs), key, backtackValues)
+			out += fmt.Sprintf("%s%q: %s,\n", repeat("\t", tabs), key, backtackValues)
 		}
 		if len(out) > 1 {
 			out = out[:len(out)-1] //Removing the Last \n
@@ -77,14 +78,10 @@ func (obj *UnstructStringConverter) runDfsUnstruct(v reflect.Value, tabs int) st
 		return fmt.Sprintf("map[string]any{\n%s\n%s}", out, repeat("\t", tabs))
 	case reflect.String:
 		data := v.String()
-		// Todo: Need much better handling to strings, Since Different combinations can lead to bad-buggy results
-		// Below Additional Replace helps in building integrity of the "" string
-		data = strings.ReplaceAll(data, "\\", "\\\\") // Replacing String containing \ with \\
-		data = strings.ReplaceAll(data, "\"", "\\\"") // Replacing String containing " with \"
 		if strings.Contains(data, "\n") {
 			return handleMultiLineStrings(data)
 		}
-		return "\"" + data + "\"" // Sending with double quotes
+		return strconv.Quote(data) // Sending with double quotes (Escaped)
 
 	case reflect.Bool:
 		return strconv.FormatBool(v.Bool()) // Return the Bool value as String
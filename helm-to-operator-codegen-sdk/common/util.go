@@ -20,6 +20,11 @@ import (
 	"errors"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -35,12 +40,60 @@ func repeat(pattern string, times int) string {
 	return out
 }
 
+/*
+Returns the keys of the map in sorted order, Used wherever the map is iterated to write go-code (So that the output is deterministic)
+*/
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/*
+Returns the alias by which the package is imported in the generated go-code (Same as of goFileImports)
+Example: k8s.io/api/autoscaling/v2 --> autoscalingv2, k8s.io/apimachinery/pkg/apis/meta/v1 --> metav1, k8s.io/apimachinery/pkg/util/intstr --> intstr
+*/
+func getPackageAlias(pkgPath string) string {
+	if pkgPath == "k8s.io/apimachinery/pkg/apis/meta/v1" {
+		return "metav1"
+	}
+	if apiPath, ok := strings.CutPrefix(pkgPath, "k8s.io/api/"); ok {
+		return strings.ReplaceAll(apiPath, "/", "")
+	}
+	return path.Base(pkgPath)
+}
+
+/*
+Returns the data-type as written in the generated go-code, The package of the named types is derived from reflect (PkgPath)
+Example: *appsv1.DeploymentSpec (Whereas reflect.Type.String() returns *v1.DeploymentSpec), []corev1.Container, map[string]string, int32
+*/
+func getGoTypeName(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name() // Predeclared types (int32, string, bool, ...)
+		}
+		return getPackageAlias(t.PkgPath()) + "." + t.Name()
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + getGoTypeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + getGoTypeName(t.Elem())
+	case reflect.Map:
+		return "map[" + getGoTypeName(t.Key()) + "]" + getGoTypeName(t.Elem())
+	}
+	return t.String()
+}
+
 /*
 Creates the directory if it  doesn't exist intially
 */
 func createDirIfDontExist(path string) error {
 	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
-		err := os.Mkdir(path, 0750)
+		err := os.MkdirAll(path, 0750)
 		if err != nil {
 			log.Println(err)
 			return err
@@ -50,22 +103,31 @@ func createDirIfDontExist(path string) error {
 }
 
 /*
-It outputs the list of filepaths of all the yaml-files present in a directory, recursively
+Returns true if the file is a kubernetes manifest file, based on its extension (.yaml, .yml, .json)
+*/
+func IsManifestFile(fileName string) bool {
+	switch filepath.Ext(fileName) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+/*
+It outputs the list of filepaths of all the manifest-files (.yaml, .yml, .json) present in a directory, recursively
+If the input is a file, then the file itself is returned
 */
-func RecursiveListYamls(curFolder string) (yamlfiles []string) {
+func RecursiveListManifests(curFolder string) (manifestFiles []string) {
+	if info, err := os.Stat(curFolder); err == nil && !info.IsDir() {
+		return []string{curFolder}
+	}
 	folderContent, _ := os.ReadDir(curFolder)
 	for _, files := range folderContent {
 		if files.Type().IsDir() {
-			if files.Name() == "tests" { //	Don't list the test yamls (if any)
-				continue
-			}
-			returnedYamlFiles := RecursiveListYamls(curFolder + "/" + files.Name())
-			yamlfiles = append(yamlfiles, returnedYamlFiles...)
-		} else {
-			fileName := files.Name()
-			if strings.HasSuffix(fileName, ".yaml") {
-				yamlfiles = append(yamlfiles, curFolder+"/"+fileName)
-			}
+			returnedManifestFiles := RecursiveListManifests(filepath.Join(curFolder, files.Name()))
+			manifestFiles = append(manifestFiles, returnedManifestFiles...)
+		} else if IsManifestFile(files.Name()) {
+			manifestFiles = append(manifestFiles, filepath.Join(curFolder, files.Name()))
 		}
 	}
 	return
@@ -74,13 +136,16 @@ func RecursiveListYamls(curFolder string) (yamlfiles []string) {
 func handleMultiLineStrings(input string) string {
 	/* There are different ways to handle Multi-Line-Strings
 	Method-1: Usage of "Str1" + "Str2"
-	Replacing "\n" with `\n " + \n "`
+	Every line is quoted (strconv.Quote) separately and concatenated with " + \n "
 	"Str1\nStr2" :
-					Str1\n" +
-					"Str2
+					"Str1\n" +
+					"Str2"
 	*/
-	input = strings.ReplaceAll(input, "\n", "\\n\" + \n \"")
-	return "\"" + input + "\""
+	lines := strings.SplitAfter(input, "\n")
+	for i, line := range lines {
+		lines[i] = strconv.Quote(line)
+	}
+	return strings.Join(lines, " + \n ")
 
 	// Method-2: Usage of `` for Raw-String Literal: To be Decided if Method 1 has any limitations
 
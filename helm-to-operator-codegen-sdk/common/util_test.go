@@ -18,7 +18,14 @@ package common
 
 import (
 	"os"
+	"reflect"
 	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestRepeat(t *testing.T) {
@@ -51,14 +58,6 @@ func TestCreateDirIfDontExist(t *testing.T) {
 	os.RemoveAll("tests/test_createdir")
 }
 
-func TestRecursiveListYamls(t *testing.T) {
-	result := RecursiveListYamls("tests")
-	if len(result) != 8 {
-		t.Errorf("Util-tests | 'RecursiveListYamls' test failed | \n Expected Length %v \n Got %v", 8, result)
-	}
-
-}
-
 func TestHandleMultiLineStrings(t *testing.T) {
 	input := "abc\nd"
 	result := handleMultiLineStrings(input)
@@ -67,3 +66,50 @@ func TestHandleMultiLineStrings(t *testing.T) {
 		t.Errorf("Util-tests | 'HandleMultiLineStrings' test failed | \n Expected %v \n Got %v", expected, result)
 	}
 }
+
+func TestIsManifestFile(t *testing.T) {
+	tests := []Tests{
+		{"a/b.yaml", true},
+		{"b.yml", true},
+		{"b.json", true},
+		{"chart.tgz", false},
+		{"NOTES.txt", false},
+	}
+	for _, test := range tests {
+		result := IsManifestFile(test.input.(string))
+		if result != test.expected.(bool) {
+			t.Errorf("Util-tests | 'IsManifestFile' test failed | Input %s | Expected %v | Got %v", test.input, test.expected, result)
+		}
+	}
+}
+
+func TestRecursiveListManifests(t *testing.T) {
+	result := RecursiveListManifests("tests/test-yamls")
+	if len(result) != 3 {
+		t.Errorf("Util-tests | 'RecursiveListManifests' test failed | \n Expected Length %v \n Got %v", 3, result)
+	}
+	result = RecursiveListManifests("tests/test-yamls/service.json")
+	if len(result) != 1 {
+		t.Errorf("Util-tests | 'RecursiveListManifests' test failed for single file | \n Expected Length %v \n Got %v", 1, result)
+	}
+}
+
+func TestGetGoTypeName(t *testing.T) {
+	tests := []Tests{
+		{reflect.TypeOf(appsv1.DeploymentSpec{}), "appsv1.DeploymentSpec"},
+		{reflect.TypeOf(&metav1.LabelSelector{}), "*metav1.LabelSelector"},
+		{reflect.TypeOf([]corev1.Container{}), "[]corev1.Container"},
+		{reflect.TypeOf(map[string]string{}), "map[string]string"},
+		{reflect.TypeOf(corev1.ResourceList{}), "corev1.ResourceList"},
+		{reflect.TypeOf(autoscalingv2.MetricSourceType("")), "autoscalingv2.MetricSourceType"},
+		{reflect.TypeOf(intstr.Type(0)), "intstr.Type"},
+		{reflect.TypeOf([]byte{}), "[]uint8"},
+		{reflect.TypeOf(int32(0)), "int32"},
+	}
+	for _, test := range tests {
+		result := getGoTypeName(test.input.(reflect.Type))
+		if result != test.expected.(string) {
+			t.Errorf("Util-tests | 'GetGoTypeName' test failed | Input %v | Expected %s | Got %s", test.input, test.expected, result)
+		}
+	}
+}
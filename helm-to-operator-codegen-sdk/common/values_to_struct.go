@@ -0,0 +1,538 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+/*
+Go-Type of the values which don't have a (complete) type: null, empty maps & lists, lists of mixed types
+Their content is preserved as it is by the API-Server (x-kubernetes-preserve-unknown-fields)
+*/
+const valuesUntypedGoType = "apiextensionsv1.JSON"
+
+// Go-Types of the scalar values, Optional fields of these types are pointers (So that an explicitly set zero value isn't overwritten by the default)
+var valuesScalarGoTypes = map[string]bool{"string": true, "bool": true, "int32": true, "int64": true, "float64": true, valuesUntypedGoType: true}
+
+type valuesField struct {
+	goName   string
+	jsonName string
+	goType   string
+	props    *apiextensionsv1.JSONSchemaProps // Schema of the field in values.schema.json, nil if not provided
+	value    any                              // Value of the field in values.yaml, nil if not provided
+	required bool
+}
+
+type valuesStruct struct {
+	name   string
+	fields []valuesField
+}
+
+/*
+Generates the kubebuilder API-Type (<Kind>, <Kind>Spec, <Kind>Status & <Kind>List) of a helm-chart, whose Spec mirrors the values of the chart
+The go-types of the fields are inferred from the values.yaml (of the chart & its subcharts), and from the values.schema.json if present (Takes precedence)
+The defaults (values.yaml) & the validations (values.schema.json) are added as kubebuilder-markers, So that controller-gen generates them in the CRD
+*/
+type ValuesStructConverter struct {
+	kind    string
+	structs []*valuesStruct // structs[0] is <Kind>Spec, The rest are the nested objects (Example: <Kind>SpecImage)
+	names   map[string]bool
+}
+
+/*
+Returns a name for the struct which isn't used by any other struct of the Kind
+*/
+func (obj *ValuesStructConverter) getUniqueName(name string) string {
+	uniqueName := name
+	for i := 2; obj.names[uniqueName]; i++ {
+		uniqueName = name + strconv.Itoa(i)
+	}
+	obj.names[uniqueName] = true
+	return uniqueName
+}
+
+/*
+Rewrites the parts of the JSON-Schema (draft-07) of values.schema.json, which can't be represented by JSONSchemaProps (openAPIV3Schema):
+type: [string, "null"] --> type: string, nullable: true & exclusiveMinimum: 5 --> minimum: 5, exclusiveMinimum: true (Same for maximum)
+*/
+func normalizeValuesSchema(schema any) {
+	switch schemaVal := schema.(type) {
+	case []any:
+		for _, item := range schemaVal {
+			normalizeValuesSchema(item)
+		}
+	case map[string]any:
+		if types, ok := schemaVal["type"].([]any); ok {
+			delete(schemaVal, "type")
+			for _, schemaType := range types {
+				if schemaType == "null" {
+					schemaVal["nullable"] = true
+				} else if _, found := schemaVal["type"]; !found {
+					schemaVal["type"] = schemaType
+				}
+			}
+		}
+		for exclusiveKey, limitKey := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+			if limit, ok := schemaVal[exclusiveKey].(float64); ok {
+				schemaVal[limitKey] = limit
+				schemaVal[exclusiveKey] = true
+			}
+		}
+		for _, value := range schemaVal {
+			normalizeValuesSchema(value)
+		}
+	}
+}
+
+/*
+Parses the values.schema.json to JSONSchemaProps
+*/
+func parseValuesSchema(data []byte) (*apiextensionsv1.JSONSchemaProps, error) {
+	var schema any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	normalizeValuesSchema(schema)
+	normalizedData, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	props := &apiextensionsv1.JSONSchemaProps{}
+	if err := json.Unmarshal(normalizedData, props); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+/*
+Returns the schema of the values of the chart (values.schema.json), Along with the schemas of its subcharts (as the property named after the subchart)
+Returns nil, if neither the chart nor any of its subcharts has a values.schema.json
+*/
+func getChartValuesSchema(chrt *chart.Chart) (*apiextensionsv1.JSONSchemaProps, error) {
+	var props *apiextensionsv1.JSONSchemaProps
+	if len(chrt.Schema) != 0 {
+		var err error
+		if props, err = parseValuesSchema(chrt.Schema); err != nil {
+			return nil, fmt.Errorf("unable to parse the values.schema.json of the helm-chart %s| %w", chrt.Name(), err)
+		}
+	}
+	for _, subchart := range chrt.Dependencies() {
+		subchartProps, err := getChartValuesSchema(subchart)
+		if err != nil {
+			return nil, err
+		}
+		if subchartProps == nil {
+			continue
+		}
+		if props == nil {
+			props = &apiextensionsv1.JSONSchemaProps{Type: "object"}
+		}
+		if props.Properties == nil {
+			props.Properties = map[string]apiextensionsv1.JSONSchemaProps{}
+		}
+		if _, found := props.Properties[subchart.Name()]; !found {
+			props.Properties[subchart.Name()] = *subchartProps
+		}
+	}
+	return props, nil
+}
+
+/*
+Returns the JSON-Schema type of the value (string, boolean, integer, number, array or object), Empty for null
+The numbers of values.yaml are decoded as float64, Therefore they are numbers (Even the whole-numbers), Unless the schema says integer
+*/
+func getValueSchemaType(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int32, int64:
+		return "integer"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return ""
+}
+
+/*
+Returns the go-type of the items of the list, Inferred from all the items if the schema of the items is not provided
+The objects are merged (So that the struct has the fields of all the items), Empty lists & lists of mixed types are untyped
+*/
+func (obj *ValuesStructConverter) getListItemType(typeName string, itemProps *apiextensionsv1.JSONSchemaProps, items []any) string {
+	itemType := ""
+	mergedItem := map[string]any{}
+	for i, item := range items {
+		curType := getValueSchemaType(item)
+		switch {
+		case i == 0 || curType == itemType:
+			itemType = curType
+		case curType+itemType == "integernumber" || curType+itemType == "numberinteger":
+			itemType = "number"
+		default:
+			itemType = ""
+		}
+		if itemMap, ok := item.(map[string]any); ok {
+			for key, value := range itemMap {
+				if _, found := mergedItem[key]; !found {
+					mergedItem[key] = value
+				}
+			}
+		}
+	}
+	if itemProps != nil && itemProps.Type != "" {
+		return obj.getGoType(typeName, itemProps, mergedItem)
+	}
+	switch itemType {
+	case "object":
+		return obj.getGoType(typeName, itemProps, mergedItem)
+	case "number":
+		return obj.getGoType(typeName, &apiextensionsv1.JSONSchemaProps{Type: "number"}, nil)
+	case "":
+		return valuesUntypedGoType
+	}
+	return obj.getGoType(typeName, itemProps, items[0])
+}
+
+/*
+Returns the go-type of the value, as per its schema (If provided), Otherwise as per the value itself
+The nested objects are added as new structs (named typeName), Example: {repository: nginx} --> <typeName>{Repository *string}
+*/
+func (obj *ValuesStructConverter) getGoType(typeName string, props *apiextensionsv1.JSONSchemaProps, value any) string {
+	schemaType := ""
+	if props != nil {
+		schemaType = props.Type
+	}
+	if schemaType == "" {
+		schemaType = getValueSchemaType(value)
+	}
+	switch schemaType {
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	case "integer":
+		if props != nil && props.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "array":
+		var itemProps *apiextensionsv1.JSONSchemaProps
+		if props != nil && props.Items != nil {
+			itemProps = props.Items.Schema
+		}
+		items, _ := value.([]any)
+		return "[]" + obj.getListItemType(typeName+"Item", itemProps, items)
+	case "object":
+		values, _ := value.(map[string]any)
+		if props != nil && props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil && len(props.Properties) == 0 {
+			return "map[string]" + obj.getGoType(typeName+"Value", props.AdditionalProperties.Schema, nil)
+		}
+		if len(values) != 0 || (props != nil && len(props.Properties) != 0) {
+			return obj.addStruct(typeName, props, values)
+		}
+	}
+	return valuesUntypedGoType
+}
+
+/*
+Adds the struct (and its nested structs) for the object, having the fields of both the values & the schema, Returns the name of the struct
+*/
+func (obj *ValuesStructConverter) addStruct(typeName string, props *apiextensionsv1.JSONSchemaProps, values map[string]any) string {
+	curStruct := &valuesStruct{name: obj.getUniqueName(typeName)}
+	obj.structs = append(obj.structs, curStruct)
+	jsonNames := map[string]bool{}
+	for jsonName := range values {
+		jsonNames[jsonName] = true
+	}
+	required := map[string]bool{}
+	if props != nil {
+		for jsonName := range props.Properties {
+			jsonNames[jsonName] = true
+		}
+		for _, jsonName := range props.Required {
+			required[jsonName] = true
+		}
+	}
+	goNames := map[string]bool{}
+	for _, jsonName := range sortedKeys(jsonNames) {
+		var fieldProps *apiextensionsv1.JSONSchemaProps
+		if props != nil {
+			if curProps, found := props.Properties[jsonName]; found {
+				fieldProps = &curProps
+			}
+		}
+		goName := getCrdGoName(jsonName)
+		for i := 2; goNames[goName]; i++ {
+			goName = getCrdGoName(jsonName) + strconv.Itoa(i)
+		}
+		goNames[goName] = true
+		curStruct.fields = append(curStruct.fields, valuesField{goName: goName, jsonName: jsonName, props: fieldProps, value: values[jsonName],
+			required: required[jsonName], goType: obj.getGoType(curStruct.name+goName, fieldProps, values[jsonName])})
+	}
+	return curStruct.name
+}
+
+/*
+Returns the value in the syntax of the kubebuilder-markers: Scalars as JSON (Example: 3, true, "nginx"), Lists of scalars as {"a","b"}
+ok is false, if the value can't be written as default of the go-type (Maps, Lists of objects & Values not matching the go-type)
+*/
+func getValuesMarkerValue(goType string, value any) (markerValue string, ok bool) {
+	if items, isList := value.([]any); isList && strings.HasPrefix(goType, "[]") {
+		itemValues := []string{}
+		for _, item := range items {
+			itemValue, ok := getValuesMarkerValue(goType[2:], item)
+			if !ok {
+				return "", false
+			}
+			itemValues = append(itemValues, itemValue)
+		}
+		return "{" + strings.Join(itemValues, ",") + "}", true
+	}
+	valueType := getValueSchemaType(value)
+	if val, isFloat := value.(float64); isFloat && val == math.Trunc(val) {
+		valueType = "integer" // A whole-number is a valid default of the integer go-types (Typed as integer by the schema)
+	}
+	switch {
+	case goType == "string" && valueType == "string", goType == "bool" && valueType == "boolean",
+		(goType == "int32" || goType == "int64") && valueType == "integer", goType == "float64" && (valueType == "integer" || valueType == "number"):
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return "", false
+		}
+		return strings.TrimSpace(buffer.String()), true
+	}
+	return "", false
+}
+
+/*
+Returns the kubebuilder-markers of the validations of the schema (Example: +kubebuilder:validation:Minimum=1, +kubebuilder:validation:Enum=a;b)
+*/
+func getValuesValidationMarkers(props *apiextensionsv1.JSONSchemaProps) []string {
+	if props == nil {
+		return nil
+	}
+	formatFloat := func(val float64) string {
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	var markers []string
+	addMarker := func(name string, value string) {
+		markers = append(markers, fmt.Sprintf("+kubebuilder:validation:%s=%s", name, value))
+	}
+	if len(props.Enum) != 0 {
+		enumValues := []string{}
+		for _, enumValue := range props.Enum {
+			enumValues = append(enumValues, string(enumValue.Raw))
+		}
+		addMarker("Enum", strings.Join(enumValues, ";"))
+	}
+	if props.Minimum != nil {
+		addMarker("Minimum", formatFloat(*props.Minimum))
+	}
+	if props.ExclusiveMinimum {
+		addMarker("ExclusiveMinimum", "true")
+	}
+	if props.Maximum != nil {
+		addMarker("Maximum", formatFloat(*props.Maximum))
+	}
+	if props.ExclusiveMaximum {
+		addMarker("ExclusiveMaximum", "true")
+	}
+	if props.MultipleOf != nil {
+		addMarker("MultipleOf", formatFloat(*props.MultipleOf))
+	}
+	if props.MinLength != nil {
+		addMarker("MinLength", strconv.FormatInt(*props.MinLength, 10))
+	}
+	if props.MaxLength != nil {
+		addMarker("MaxLength", strconv.FormatInt(*props.MaxLength, 10))
+	}
+	if props.Pattern != "" {
+		if strings.Contains(props.Pattern, "`") {
+			addMarker("Pattern", strconv.Quote(props.Pattern))
+		} else {
+			addMarker("Pattern", "`"+props.Pattern+"`")
+		}
+	}
+	if props.Format != "" {
+		addMarker("Format", props.Format)
+	}
+	if props.MinItems != nil {
+		addMarker("MinItems", strconv.FormatInt(*props.MinItems, 10))
+	}
+	if props.MaxItems != nil {
+		addMarker("MaxItems", strconv.FormatInt(*props.MaxItems, 10))
+	}
+	if props.UniqueItems {
+		addMarker("UniqueItems", "true")
+	}
+	if props.MinProperties != nil {
+		addMarker("MinProperties", strconv.FormatInt(*props.MinProperties, 10))
+	}
+	if props.MaxProperties != nil {
+		addMarker("MaxProperties", strconv.FormatInt(*props.MaxProperties, 10))
+	}
+	if props.Nullable {
+		markers = append(markers, "+nullable")
+	}
+	return markers
+}
+
+/*
+Returns the go-code of the field, along with its description & kubebuilder-markers
+The optional fields (Not required by the schema) are omitempty, The optional scalars & nested structs are pointers (So that the fields which are not set are omitted)
+The default is the value of values.yaml (Otherwise of the schema), The nested structs are defaulted to {} (So that the defaults of their fields are applied)
+*/
+func getValuesFieldDefinition(field valuesField) string {
+	var comments []string
+	if field.props != nil && field.props.Description != "" {
+		comments = append(comments, strings.Split(strings.TrimSpace(field.props.Description), "\n")...)
+	}
+	comments = append(comments, getValuesValidationMarkers(field.props)...)
+	goType, jsonTag := field.goType, field.jsonName
+	isStruct := !valuesScalarGoTypes[field.goType] && !strings.HasPrefix(field.goType, "[]") && !strings.HasPrefix(field.goType, "map[")
+	if field.required {
+		comments = append(comments, "+kubebuilder:validation:Required")
+	} else {
+		comments = append(comments, "+optional")
+		jsonTag += ",omitempty"
+		if valuesScalarGoTypes[goType] || isStruct {
+			goType = "*" + goType
+		}
+	}
+	if goType == valuesUntypedGoType || goType == "*"+valuesUntypedGoType {
+		comments = append(comments, "+kubebuilder:pruning:PreserveUnknownFields")
+	}
+	defaultValue := field.value
+	if defaultValue == nil && field.props != nil && field.props.Default != nil {
+		_ = json.Unmarshal(field.props.Default.Raw, &defaultValue)
+	}
+	if markerValue, ok := getValuesMarkerValue(field.goType, defaultValue); ok {
+		comments = append(comments, "+kubebuilder:default="+markerValue)
+	} else if isStruct {
+		comments = append(comments, "+kubebuilder:default={}")
+	}
+	out := ""
+	for _, comment := range comments {
+		out += "\t// " + comment + "\n"
+	}
+	out += fmt.Sprintf("\t%s %s `json:\"%s\"`\n", field.goName, goType, jsonTag)
+	return out
+}
+
+/*
+Returns the go-code of the API-Type: <Kind>Spec (& its nested structs), <Kind>Status, <Kind>, <Kind>List and ToValues of <Kind>Spec
+*/
+func (obj *ValuesStructConverter) getTypeDefinitions(chrt *chart.Chart) string {
+	kind := obj.kind
+	out := fmt.Sprintf(`
+/*
+%sSpec is generated from the values of the helm-chart %s (%s), The defaults are of its values.yaml and the validations of its values.schema.json
+Therefore the chart can be configured through the Custom-Resource %s (See ToValues), Instead of regenerating the go-code
+*/`, kind, chrt.Name(), chrt.Metadata.Version, kind)
+	for i, curStruct := range obj.structs {
+		fields := ""
+		for j, field := range curStruct.fields {
+			if j != 0 {
+				fields += "\n"
+			}
+			fields += getValuesFieldDefinition(field)
+		}
+		if i != 0 {
+			out += "\n"
+		}
+		out += fmt.Sprintf("\ntype %s struct {\n%s}\n", curStruct.name, fields)
+	}
+	out += fmt.Sprintf(`
+// %sStatus defines the observed state of %s
+type %sStatus struct {
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// %s is the Schema of the Custom-Resource configuring the helm-chart %s
+type %s struct {
+	metav1.TypeMeta   `+"`json:\",inline\"`"+`
+	metav1.ObjectMeta `+"`json:\"metadata,omitempty\"`"+`
+
+	Spec   %sSpec   `+"`json:\"spec,omitempty\"`"+`
+	Status %sStatus `+"`json:\"status,omitempty\"`"+`
+}
+
+// +kubebuilder:object:root=true
+
+// %sList contains a list of %s
+type %sList struct {
+	metav1.TypeMeta `+"`json:\",inline\"`"+`
+	metav1.ListMeta `+"`json:\"metadata,omitempty\"`"+`
+	Items           []%s `+"`json:\"items\"`"+`
+}
+
+/*
+ToValues returns the Spec as the values of the helm-chart %s, The optional fields which are not set (nil, empty lists & maps) are omitted (Therefore the defaults of the chart are used)
+*/
+func (in *%sSpec) ToValues() (map[string]any, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]any{}
+	err = json.Unmarshal(data, &values)
+	return values, err
+}
+`, kind, kind, kind, kind, chrt.Name(), kind, kind, kind, kind, kind, kind, kind, chrt.Name(), kind)
+	return out
+}
+
+/*
+Input: The Helm-Chart (Example: HelmYamlConvertor.Chart)
+Output: The go-code of the kubebuilder API-Type of the chart (Kind: Name of the chart, Example: hello-world --> HelloWorld), whose Spec mirrors the values of the chart
+*/
+func (obj *ValuesStructConverter) Convert(chrt *chart.Chart) (string, error) {
+	obj.kind = getCrdGoName(chrt.Name())
+	obj.structs = nil
+	obj.names = map[string]bool{obj.kind: true, obj.kind + "Status": true, obj.kind + "List": true}
+	values, err := chartutil.CoalesceValues(chrt, map[string]any{})
+	if err != nil {
+		return "", fmt.Errorf("unable to read the values of the helm-chart %s| %w", chrt.Name(), err)
+	}
+	props, err := getChartValuesSchema(chrt)
+	if err != nil {
+		return "", err
+	}
+	obj.addStruct(obj.kind+"Spec", props, values)
+	return obj.getTypeDefinitions(chrt), nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+)
+
+func TestValuesStructConverter(t *testing.T) {
+	chrt, err := loader.Load("tests/test-helmCharts/values-schema")
+	if err != nil {
+		t.Fatalf("Unable to load the helm-chart| Error %v", err)
+	}
+	var valuesStructConverterObj ValuesStructConverter
+	result, err := valuesStructConverterObj.Convert(chrt)
+	if err != nil {
+		t.Fatalf("Unable to generate the values-types| Error %v", err)
+	}
+	for _, expected := range []string{"type ValuesSchemaSpec struct {", "type ValuesSchema struct {", "type ValuesSchemaList struct {",
+		"// Number of replicas of the deployment\n\t// +kubebuilder:validation:Minimum=1\n\t// +optional\n\t// +kubebuilder:default=3\n\tReplicaCount *int64 `json:\"replicaCount,omitempty\"`",
+		"// +kubebuilder:validation:Required\n\t// +kubebuilder:default={}\n\tImage ValuesSchemaSpecImage `json:\"image\"`",
+		"// +optional\n\t// +kubebuilder:default={}\n\tService *ValuesSchemaSpecService `json:\"service,omitempty\"`",
+		"// +kubebuilder:validation:Enum=\"Always\";\"IfNotPresent\";\"Never\"", "// +kubebuilder:default=\"IfNotPresent\"",
+		"// +kubebuilder:validation:Pattern=`^[a-z0-9-]*$`\n\t// +nullable",
+		"// +kubebuilder:validation:Maximum=65536\n\t// +kubebuilder:validation:ExclusiveMaximum=true",
+		"Annotations map[string]string `json:\"annotations,omitempty\"`", "func (in *ValuesSchemaSpec) ToValues() (map[string]any, error) {"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("'%s' Not Found in the values-types| Actual Output : %s \n", expected, result)
+		}
+	}
+}
+
+func TestValuesStructConverterWithoutSchema(t *testing.T) {
+	chrt := &chart.Chart{Metadata: &chart.Metadata{Name: "my-app", Version: "1.0.0"}, Values: map[string]any{
+		"replicas":     float64(2),
+		"count":        3,
+		"ratio":        0.5,
+		"tolerations":  []any{},
+		"args":         []any{"--a", "--b"},
+		"ports":        []any{map[string]any{"name": "http"}, map[string]any{"port": float64(80)}},
+		"nodeSelector": map[string]any{},
+		"extra":        nil,
+	}}
+	var valuesStructConverterObj ValuesStructConverter
+	result, err := valuesStructConverterObj.Convert(chrt)
+	if err != nil {
+		t.Fatalf("Unable to generate the values-types| Error %v", err)
+	}
+	tests := []Tests{
+		{"whole-number without schema", "// +kubebuilder:default=2\n\tReplicas *float64"},
+		{"ratio", "// +kubebuilder:default=0.5\n\tRatio *float64"},
+		{"list of scalars", "// +kubebuilder:default={\"--a\",\"--b\"}\n\tArgs []string"},
+		{"list of objects (merged)", "Ports []MyAppSpecPortsItem"},
+		{"merged fields", "Name *string `json:\"name,omitempty\"`"},
+		{"merged fields", "Port *float64 `json:\"port,omitempty\"`"},
+		{"integer of values", "// +kubebuilder:default=3\n\tCount *int64"},
+		{"empty list", "Tolerations []apiextensionsv1.JSON"},
+		{"empty map", "// +kubebuilder:pruning:PreserveUnknownFields\n\tNodeSelector *apiextensionsv1.JSON"},
+		{"null", "Extra *apiextensionsv1.JSON"},
+	}
+	for _, test := range tests {
+		if !strings.Contains(result, test.expected.(string)) {
+			t.Errorf("Values-Types Failed for %s | Expected %s | Actual Output : %s \n", test.input, test.expected, result)
+		}
+	}
+}
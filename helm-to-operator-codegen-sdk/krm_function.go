@@ -0,0 +1,216 @@
+/*
+Copyright 2023 The Nephio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"helm_to_controller/packages/common"
+	"io"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/fn/framework"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	krmFunctionDefaultOutputName = "generated-code" // Name of the output ConfigMap, If not provided in functionConfig
+	krmFunctionOutputAnnotation  = "nephio.org/generated-by"
+	krmFunctionOutputGenerator   = "helm-to-operator-codegen-sdk"
+	localConfigAnnotation        = "config.kubernetes.io/local-config"
+)
+
+/*
+Configuration of the KRM Function, Read from the data of functionConfig (ConfigMap)
+Example:
+
+	data:
+	  name: amf-generated-code  // Name of the output ConfigMap (Default: generated-code)
+	  namespace: amf            // Namespace used by the ApplyAll/CreateAll/DeleteAll of the go-code, Same as of --namespace of CLI
+	  package: amf              // Same as of --package of CLI
+	  file-name: amf.go         // Same as of --file-name of CLI, Key of the output ConfigMap containing the go-code
+	  split-by-kind: "true"     // Same as of --split-by-kind of CLI, Each file is added as a separate key in the output ConfigMap
+	  type-check: "false"       // Same as of --type-check of CLI (Default: true)
+	  field-manager: amf-op     // Same as of --field-manager of CLI
+*/
+type krmFunctionConfig struct {
+	name         string
+	namespace    string
+	packageName  string
+	fileName     string
+	splitByKind  bool
+	typeCheck    bool
+	fieldManager string
+}
+
+func getKrmFunctionConfig(functionConfig *yaml.RNode) krmFunctionConfig {
+	config := krmFunctionConfig{name: krmFunctionDefaultOutputName, typeCheck: true}
+	if functionConfig == nil || functionConfig.IsNilOrEmpty() {
+		return config
+	}
+	data := functionConfig.GetDataMap()
+	if data["name"] != "" {
+		config.name = data["name"]
+	}
+	config.namespace = data["namespace"]
+	config.packageName = data["package"]
+	config.fileName = data["file-name"]
+	config.splitByKind, _ = strconv.ParseBool(data["split-by-kind"])
+	config.fieldManager = data["field-manager"]
+	if typeCheck, err := strconv.ParseBool(data["type-check"]); err == nil {
+		config.typeCheck = typeCheck
+	}
+	return config
+}
+
+/*
+Returns true if the item is the output ConfigMap (Generated by the previous run of the KRM Function)
+*/
+func isKrmFunctionOutput(item *yaml.RNode) bool {
+	return item.GetKind() == "ConfigMap" && item.GetAnnotations()[krmFunctionOutputAnnotation] == krmFunctionOutputGenerator
+}
+
+/*
+Returns a copy of the item without the annotations added by the orchestrator (kpt/porch),
+So that they don't end up in the go-code
+*/
+func stripOrchestratorAnnotations(item *yaml.RNode) (*yaml.RNode, error) {
+	itemCopy := item.Copy()
+	for key := range itemCopy.GetAnnotations() {
+		if strings.HasPrefix(key, "internal.config.kubernetes.io/") || strings.HasPrefix(key, "config.kubernetes.io/") ||
+			key == kioutil.LegacyIdAnnotation {
+			if err := itemCopy.PipeE(yaml.ClearAnnotation(key)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return itemCopy, nil
+}
+
+func newKrmFunctionResult(item *yaml.RNode, severity framework.Severity, message string) *framework.Result {
+	result := &framework.Result{Message: message, Severity: severity}
+	if meta, err := item.GetMeta(); err == nil {
+		resourceRef := meta.GetIdentifier()
+		result.ResourceRef = &resourceRef
+	}
+	if path, index, err := kioutil.GetFileAnnotations(item); err == nil && path != "" {
+		result.File = &framework.File{Path: path}
+		result.File.Index, _ = strconv.Atoi(index)
+	}
+	return result
+}
+
+/*
+Processes the ResourceList: Converts all the items to go-code, and adds (or replaces) the output ConfigMap containing
+the go-code (data["generated_code.go"], or a key per file if split-by-kind), Items which couldn't be converted are reported in the results (Severity: warning)
+Items having the "config.kubernetes.io/local-config" annotation are not deployed, Therefore no go-code is generated for them
+The Custom-Resources whose CRD is an item (local-config or not) are converted to typed go-structs
+*/
+func processResourceList(rl *framework.ResourceList) error {
+	config := getKrmFunctionConfig(rl.FunctionConfig)
+	var resourceConverterObj = newResourceConverter()
+	if config.typeCheck {
+		if err := resourceConverterObj.enableTypeCheck(); err != nil {
+			return err
+		}
+	}
+	// The CRDs (Even the local-config ones) are added first, So that their Custom-Resources are converted to typed go-structs
+	for _, item := range rl.Items {
+		if item.GetKind() == "CustomResourceDefinition" && !isKrmFunctionOutput(item) {
+			if itemString, err := item.String(); err == nil {
+				source, _, _ := kioutil.GetFileAnnotations(item)
+				resourceConverterObj.addCrds(source, []byte(itemString))
+			}
+		}
+	}
+	outputIndex := -1
+	for i, item := range rl.Items {
+		if isKrmFunctionOutput(item) {
+			outputIndex = i
+			continue
+		}
+		if item.GetAnnotations()[localConfigAnnotation] == "true" {
+			continue
+		}
+		itemCopy, err := stripOrchestratorAnnotations(item)
+		if err != nil {
+			rl.Results = append(rl.Results, newKrmFunctionResult(item, framework.Warning, fmt.Sprintf("Unable to read the resource (Skipped)| %v", err)))
+			continue
+		}
+		itemString, err := itemCopy.String()
+		if err != nil {
+			rl.Results = append(rl.Results, newKrmFunctionResult(item, framework.Warning, fmt.Sprintf("Unable to read the resource (Skipped)| %v", err)))
+			continue
+		}
+
+		source, _, _ := kioutil.GetFileAnnotations(item)
+		skippedBefore := len(resourceConverterObj.skipped)
+		resourceConverterObj.convertYaml(source, []byte(itemString))
+		for _, skipped := range resourceConverterObj.skipped[skippedBefore:] {
+			rl.Results = append(rl.Results, newKrmFunctionResult(item, framework.Warning,
+				fmt.Sprintf("Unable to convert the resource to go-code (Skipped)| %s", skipped.reason)))
+		}
+	}
+
+	var goFileObj = common.GoFile{Namespace: config.namespace, PackageName: config.packageName, FileName: config.fileName, SplitByKind: config.splitByKind,
+		FieldManager: config.fieldManager}
+	goFileObj.Intialise(runtimeSupportKinds)
+	if err := resourceConverterObj.generateGoFile(&goFileObj); err != nil {
+		return err
+	}
+
+	output := yaml.NewMapRNode(nil)
+	output.SetApiVersion("v1")
+	output.SetKind("ConfigMap")
+	if err := output.SetName(config.name); err != nil {
+		return err
+	}
+	if err := output.SetAnnotations(map[string]string{
+		localConfigAnnotation:                "true",
+		krmFunctionOutputAnnotation:          krmFunctionOutputGenerator,
+		string(kioutil.PathAnnotation):       config.name + ".yaml",
+		string(kioutil.LegacyPathAnnotation): config.name + ".yaml",
+	}); err != nil {
+		return err
+	}
+	output.SetDataMap(goFileObj.Files)
+	if outputIndex >= 0 {
+		rl.Items[outputIndex] = output
+	} else {
+		rl.Items = append(rl.Items, output)
+	}
+
+	convertedCount := 0
+	for _, gocodes := range resourceConverterObj.gocodes {
+		convertedCount += len(gocodes)
+	}
+	rl.Results = append(rl.Results, &framework.Result{Severity: framework.Info,
+		Message: fmt.Sprintf("Generated go-code for %d resources in ConfigMap %s", convertedCount, config.name)})
+	return nil
+}
+
+/*
+Runs the SDK as a KRM Function: Reads the ResourceList (config.kubernetes.io/v1) from input,
+and writes the processed ResourceList (containing the output ConfigMap & results) to output
+Note: Logs are written to stderr (logrus default), Therefore they don't interfere with the ResourceList
+*/
+func runKrmFunction(input io.Reader, output io.Writer) error {
+	return framework.Execute(framework.ResourceListProcessorFunc(processResourceList),
+		&kio.ByteReadWriter{Reader: input, Writer: output, KeepReaderAnnotations: true})
+}
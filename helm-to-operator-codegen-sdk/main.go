@@ -17,15 +17,21 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"helm_to_controller/packages/common"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/liyue201/gostl/ds/set"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/sirupsen/logrus"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -33,15 +39,36 @@ import (
 	"k8s.io/kubectl/pkg/scheme"
 )
 
-var runtimeSupportKinds = []string{"Deployment", "Service", "Secret", "Role", "RoleBinding", "ClusterRoleBinding",
-	"PersistentVolumeClaim", "StatefulSet", "ServiceAccount", "ClusterRole", "PriorityClass", "ConfigMap"}
+/*
+Annotation overriding the install-order of a resource: The resources are created in the order of their install-weight (Default: 0, Can be negative),
+Resources having the same install-weight are created in the install-order of their kind (Same as of helm), And deleted in the reverse-order
+*/
+const installWeightAnnotation = "nephio.org/install-weight"
+
+var runtimeSupportKinds []string // To be set by init (From the kinds registered in client-go scheme)
 var runtimeSupportKindSet = set.New[string](comparator.StringComparator, set.WithGoroutineSafe())
 
 func init() {
-	// Runtime Support Kind Set contains the set of all kinds whose runtime-support is handled by the script
-	for _, val := range runtimeSupportKinds {
-		runtimeSupportKindSet.Insert(val)
+	// Runtime Support Kind Set contains the set of all kinds whose runtime-support is handled by the script:
+	// All the kinds (Objects having ObjectMeta, Except Lists) registered in the client-go scheme (Of any Api-Version: v1, v2, v1beta1, ...)
+	for gvk, objType := range scheme.Scheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal || strings.HasSuffix(gvk.Kind, "List") || runtimeSupportKindSet.Contains(gvk.Kind) {
+			continue
+		}
+		if _, isObject := reflect.New(objType).Interface().(metav1.Object); isObject {
+			runtimeSupportKindSet.Insert(gvk.Kind)
+			runtimeSupportKinds = append(runtimeSupportKinds, gvk.Kind)
+		}
 	}
+	sort.Strings(runtimeSupportKinds)
+}
+
+/*
+Returns true if the KRM Resource would be converted to typed go-struct (Runtime-Object method),
+Otherwise it is treated as Third Party Kind (unstructured.Unstructured method)
+*/
+func isRuntimeSupported(gvk schema.GroupVersionKind) bool {
+	return runtimeSupportKindSet.Contains(gvk.Kind) && scheme.Scheme.Recognizes(gvk)
 }
 
 /*
@@ -61,12 +88,7 @@ func unstructuredDecode(data []byte) (*unstructured.Unstructured, *schema.GroupV
 
 /*
 Input: Reads the yaml file from filepath
-Output:
-
-	runtimeObjList: List of runtime Objects Converted from the input yaml
-	gvkList		: List of Group-Version-Kind for the runtime objects of runtimeObjList, mapped Index-wise
-	unstructObjList: List of unstructured Objects Converted from the input yaml, whose Kind are not default to kubernetes| Third Party Kinds
-	unstructGvkList: List of Group-Version-Kind for the unstructured objects of unstructObjList, mapped Index-wise
+Output: Same as of handleYamlContent
 */
 func handleSingleYaml(inputFilepath string) (runtimeObjList []runtime.Object, gvkList []schema.GroupVersionKind, unstructObjList []unstructured.Unstructured, unstructGvkList []schema.GroupVersionKind) {
 	data, err := common.GetFileContents(inputFilepath)
@@ -74,9 +96,36 @@ func handleSingleYaml(inputFilepath string) (runtimeObjList []runtime.Object, gv
 		logrus.Error("Error While Reading YAML file | ", inputFilepath, " \t |", err)
 		return
 	}
+	return handleYamlContent(data)
+}
+
+/*
+Returns true if the yaml-document doesn't contain any KRM Resource (Only whitespaces, comments or separator),
+Example: Helm-templates which are disabled by the values renders to "# Source: <template-path>" only
+*/
+func isEmptyYamlDocument(doc string) bool {
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && line != "---" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Input: Content of a yaml/json file or a multi-document stream (documents separated by ---)
+Output:
+
+	runtimeObjList: List of runtime Objects Converted from the input yaml
+	gvkList		: List of Group-Version-Kind for the runtime objects of runtimeObjList, mapped Index-wise
+	unstructObjList: List of unstructured Objects Converted from the input yaml, whose Kind are not default to kubernetes| Third Party Kinds
+	unstructGvkList: List of Group-Version-Kind for the unstructured objects of unstructObjList, mapped Index-wise
+*/
+func handleYamlContent(data []byte) (runtimeObjList []runtime.Object, gvkList []schema.GroupVersionKind, unstructObjList []unstructured.Unstructured, unstructGvkList []schema.GroupVersionKind) {
 	// A Single yaml can contain muliple KRM reosurces, separated by ---, Therefore Spliting the yaml-file-content over "---" to get single  KRM Resource
 	for _, doc := range strings.Split(string(data), "\n---") {
-		if doc == "" {
+		if isEmptyYamlDocument(doc) {
 			continue
 		}
 		// Parsing the KRM Resource to get the Kind which will decide to use either runtime-object-method or unstructured.Unstructured method
@@ -86,7 +135,7 @@ func handleSingleYaml(inputFilepath string) (runtimeObjList []runtime.Object, gv
 			continue
 		}
 		resourceKind := gvk.Kind
-		if runtimeSupportKindSet.Contains(resourceKind) {
+		if isRuntimeSupported(*gvk) {
 			// Handle the current yaml with runtimeObject method
 			decoder := scheme.Codecs.UniversalDeserializer()
 			runtimeObject, gvk, err := decoder.Decode([]byte(doc), nil, nil)
@@ -105,85 +154,412 @@ func handleSingleYaml(inputFilepath string) (runtimeObjList []runtime.Object, gv
 	return
 }
 
-func setLogLevel(loggingLvl string) {
-	ll, err := logrus.ParseLevel(loggingLvl)
+/*
+Returns true if the input should be treated as plain kubernetes manifests (bypassing helm):
+A Single .yaml/.yml/.json file, or a directory which is not a helm-chart (doesn't contain Chart.yaml)
+*/
+func isManifestInput(inputPath string) bool {
+	info, err := os.Stat(inputPath)
 	if err != nil {
-		ll = logrus.DebugLevel
+		return false
 	}
-	logrus.SetLevel(ll)
+	if !info.IsDir() {
+		return common.IsManifestFile(inputPath)
+	}
+	_, err = os.Stat(filepath.Join(inputPath, "Chart.yaml"))
+	return errors.Is(err, os.ErrNotExist)
 }
 
-func main() {
-	curHelmChart := "inputs"
-	cmdArgs := os.Args[1:]
-	if len(cmdArgs) != 0 {
-		curHelmChart = cmdArgs[0]
-	}
-	namespace := ""
-	if len(cmdArgs) >= 2 {
-		namespace = cmdArgs[1]
-	}
+/*
+Details of a KRM Resource which couldn't be converted to go-code
+*/
+type skippedResource struct {
+	source string // Yaml-File (or stdin/kustomization) the resource belongs to
+	gvk    schema.GroupVersionKind
+	name   string
+	reason string
+}
+
+/*
+Result of the round-trip verification of a KRM Resource: The differences between the object of its go-code and the decoded resource
+*/
+type roundTripResult struct {
+	source   string
+	gvk      schema.GroupVersionKind
+	name     string
+	diffs    []string
+	dropped  []string // Helm-Specific-Labels dropped on purpose by the RuntimeJsonConverter (Not counted as differences)
+	verified bool     // False if the go-code of the resource can't be evaluated (The data-types of its typed go-structs can't be built, See verifyTypedRoundTrip)
+	reason   string   // Why the go-code couldn't be evaluated, If not verified
+}
 
-	loggingLvl := "info"
-	if len(cmdArgs) >= 3 {
-		loggingLvl = cmdArgs[2]
+/*
+Converts the KRM Resources to go-code using the Convertors of common package
+gocodes: Map of Group-Version-Kind as Key and the go-codes of all the resources of that GVK as Value, Sorted by name & namespace (Used by GoFile.Generate, See generateGoFile)
+gocodeKeys: Map of Group-Version-Kind as Key and the "<name>/<namespace>" of the resources as Value, Same order as of gocodes (See addGoCode)
+installWeights: Map of Group-Version-Kind as Key and the install-weight (installWeightAnnotation) of the resources as Value, Same order as of gocodes (Used by GoFile.Generate)
+typeDefinitions: Map of Group-Version-Kind as Key and the go-code of its typed go-structs as Value (Custom-Resources whose CRD is known, Used by GoFile.Generate)
+skipped: List of all the resources which couldn't be converted
+goCodeCheckerObj: Type-Checks the go-code of each resource, nil if the type-check is disabled (See enableTypeCheck)
+goCodeEvaluatorObj & roundTrips: Round-Trip Verification of the go-code of each resource, nil if it is disabled (See enableRoundTrip)
+*/
+type resourceConverter struct {
+	jsonStringConverterObj     common.JsonStringConverter
+	runtimeJsonConverterObj    common.RuntimeJsonConverter
+	unstructStringConverterObj common.UnstructStringConverter
+	crdStructConverterObj      common.CrdStructConverter
+	goTypeConverterObj         common.GoTypeConverter
+	goCodeCheckerObj           *common.GoCodeChecker
+	goCodeEvaluatorObj         *common.GoCodeEvaluator
+	gocodes                    map[schema.GroupVersionKind][]string
+	gocodeKeys                 map[schema.GroupVersionKind][]string
+	installWeights             map[schema.GroupVersionKind][]int
+	typeDefinitions            map[schema.GroupVersionKind]string
+	skipped                    []skippedResource
+	roundTrips                 []roundTripResult
+}
+
+func newResourceConverter() *resourceConverter {
+	obj := &resourceConverter{gocodes: map[schema.GroupVersionKind][]string{}, gocodeKeys: map[schema.GroupVersionKind][]string{},
+		installWeights: map[schema.GroupVersionKind][]int{}, typeDefinitions: map[schema.GroupVersionKind]string{}}
+	obj.jsonStringConverterObj.Intialise()
+	return obj
+}
+
+/*
+Converts the runtime-objects and unstructured-objects (As returned by handleYamlContent) to go-code
+*/
+func (obj *resourceConverter) convert(yamlSource string, runtimeObjList []runtime.Object, gvkList []schema.GroupVersionKind, unstructObjList []unstructured.Unstructured, unstructGvkList []schema.GroupVersionKind) {
+	for i := 0; i < len(runtimeObjList); i++ {
+		logrus.Info(fmt.Sprintf(" Current KRM Resource| Kind : %s| YamlFilePath : %s", gvkList[i].Kind, yamlSource))
+		jsonMap, err := obj.runtimeJsonConverterObj.Convert(runtimeObjList[i], gvkList[i])
+		if err != nil {
+			logrus.Error("\t Converting Runtime to Json Failed (Skipping Current Resource)| Error : ", err)
+			obj.skip(yamlSource, runtimeObjList[i], gvkList[i], err)
+			continue
+		}
+
+		logrus.Info("\t Converting Runtime to Json Completed")
+		gocodeStr, err := obj.jsonStringConverterObj.Convert(gvkList[i], jsonMap)
+		if err != nil {
+			logrus.Info("\t Converting Json to String Failed (Skipping Current Resource)| Error : ", err)
+			obj.skip(yamlSource, runtimeObjList[i], gvkList[i], err)
+			continue
+		}
+		if err := obj.checkGoCode(gocodeStr, ""); err != nil {
+			logrus.Error("\t Type-Check of Go-Code Failed (Skipping Current Resource)| Error : ", err)
+			obj.skip(yamlSource, runtimeObjList[i], gvkList[i], err)
+			continue
+		}
+		obj.addGoCode(gvkList[i], runtimeObjList[i], gocodeStr)
+		obj.verifyRoundTrip(yamlSource, gvkList[i], runtimeObjList[i], gocodeStr)
+		logrus.Info("\t Converting Json to String Completed ")
 	}
-	setLogLevel(loggingLvl)
 
-	var helmYamlConvertor = common.HelmYamlConvertor{Namespace: namespace, Chartpath: curHelmChart}
-	err := helmYamlConvertor.ConvertHelmToYaml()
-	if err != nil {
-		logrus.Fatal("Unable to Convert Helm to Yamls| Error | ", err)
-	}
-	allYamlPaths := common.RecursiveListYamls("temp/templated")
-	// Intialising Convertor Structs/Classes
-	var jsonStringConverterObj = common.JsonStringConverter{}
-	jsonStringConverterObj.Intialise()
-	var goFileObj = common.GoFile{Namespace: namespace}
-	goFileObj.Intialise(runtimeSupportKinds)
-	var runtimeJsonConverterObj = common.RuntimeJsonConverter{}
-	var unstructStringConverterObj = common.UnstructStringConverter{}
-
-	// Loop over each Yaml File (recursively) and get their gocodes
-	var gocodes = map[string][]string{}
-	for _, yamlfile := range allYamlPaths {
-		logrus.Info("CurFile --> | ", yamlfile)
-		runtimeObjList, gvkList, unstructObjList, unstructGvkList := handleSingleYaml(yamlfile)
-		for i := 0; i < len(runtimeObjList); i++ {
-			logrus.Info(fmt.Sprintf(" Current KRM Resource| Kind : %s| YamlFilePath : %s", gvkList[i].Kind, yamlfile))
-			err := runtimeJsonConverterObj.Convert(runtimeObjList[i], gvkList[i])
+	for i := 0; i < len(unstructObjList); i++ {
+		if obj.goTypeConverterObj.IsKnown(unstructGvkList[i]) {
+			// Third-Party Kind whose Go-Type is configured (--go-types): Converted to the Go-Type of its published Go-Package
+			jsonMap, err := obj.goTypeConverterObj.Convert(unstructObjList[i])
 			if err != nil {
-				logrus.Error("\t Converting Runtime to Json Failed (Skipping Current Resource)| Error : ", err)
+				logrus.Error("\t Converting Resource to Json Failed (Skipping Current Resource)| Error : ", err)
+				obj.skipped = append(obj.skipped, skippedResource{source: yamlSource, gvk: unstructGvkList[i], name: unstructObjList[i].GetName(), reason: err.Error()})
 				continue
 			}
-
-			logrus.Info("\t Converting Runtime to Json Completed")
-			gocodeStr, err := jsonStringConverterObj.Convert(gvkList[i])
+			gocodeStr, err := obj.jsonStringConverterObj.ConvertType(obj.goTypeConverterObj.GetTypeName(unstructGvkList[i]), jsonMap)
+			if err == nil {
+				err = obj.checkGoCode(gocodeStr, "")
+			}
+			if err != nil {
+				logrus.Error("\t Converting Json to String Failed (Skipping Current Resource)| Error : ", err)
+				obj.skipped = append(obj.skipped, skippedResource{source: yamlSource, gvk: unstructGvkList[i], name: unstructObjList[i].GetName(), reason: err.Error()})
+				continue
+			}
+			obj.addGoCode(unstructGvkList[i], &unstructObjList[i], gocodeStr)
+			obj.verifyTypedRoundTrip(yamlSource, unstructGvkList[i], &unstructObjList[i], gocodeStr, obj.goTypeConverterObj.GetGoTypes)
+			logrus.Info("\t Converting Resource to Go-Type Completed ")
+			continue
+		}
+		if obj.crdStructConverterObj.IsKnown(unstructGvkList[i]) {
+			// Custom-Resource whose CRD is known: Converted to the typed go-struct (generated from the openAPIV3Schema of the CRD)
+			jsonMap, err := obj.crdStructConverterObj.Convert(unstructObjList[i])
 			if err != nil {
-				logrus.Info("\t Converting Json to String Failed (Skipping Current Resource)| Error : ", err)
+				logrus.Error("\t Converting Custom-Resource to Json Failed (Skipping Current Resource)| Error : ", err)
+				obj.skipped = append(obj.skipped, skippedResource{source: yamlSource, gvk: unstructGvkList[i], name: unstructObjList[i].GetName(), reason: err.Error()})
 				continue
 			}
-			gocodes[gvkList[i].Kind] = append(gocodes[gvkList[i].Kind], gocodeStr)
-			logrus.Info("\t Converting Json to String Completed ")
+			typeDefinitions := obj.crdStructConverterObj.GetTypeDefinitions(unstructGvkList[i])
+			gocodeStr, err := obj.jsonStringConverterObj.ConvertType(unstructGvkList[i].Kind, jsonMap)
+			if err == nil {
+				err = obj.checkGoCode(gocodeStr, typeDefinitions)
+			}
+			if err != nil {
+				logrus.Error("\t Converting Json to String Failed (Skipping Current Resource)| Error : ", err)
+				obj.skipped = append(obj.skipped, skippedResource{source: yamlSource, gvk: unstructGvkList[i], name: unstructObjList[i].GetName(), reason: err.Error()})
+				continue
+			}
+			obj.addGoCode(unstructGvkList[i], &unstructObjList[i], gocodeStr)
+			obj.typeDefinitions[unstructGvkList[i]] = typeDefinitions
+			obj.verifyTypedRoundTrip(yamlSource, unstructGvkList[i], &unstructObjList[i], gocodeStr, obj.crdStructConverterObj.GetGoTypes)
+			logrus.Info("\t Converting Custom-Resource to String Completed ")
+			continue
+		}
+		gocode := obj.unstructStringConverterObj.Convert(unstructObjList[i])
+		if err := obj.checkGoCode(gocode, ""); err != nil {
+			logrus.Error("\t Type-Check of Go-Code Failed (Skipping Current Resource)| Error : ", err)
+			obj.skipped = append(obj.skipped, skippedResource{source: yamlSource, gvk: unstructGvkList[i], name: unstructObjList[i].GetName(), reason: err.Error()})
+			continue
+		}
+		obj.addGoCode(unstructGvkList[i], &unstructObjList[i], gocode)
+		obj.verifyRoundTrip(yamlSource, unstructGvkList[i], &unstructObjList[i], gocode)
+		logrus.Info("\t Converting Unstructured to String Completed ")
+	}
+}
+
+/*
+Decodes the yaml-content (As of handleYamlContent) and converts it to go-code,
+Documents which couldn't be decoded are also reported as skipped
+*/
+func (obj *resourceConverter) convertYaml(yamlSource string, data []byte) {
+	for _, doc := range strings.Split(string(data), "\n---") {
+		if isEmptyYamlDocument(doc) {
+			continue
+		}
+		runtimeObjList, gvkList, unstructObjList, unstructGvkList := handleYamlContent([]byte(doc))
+		if len(runtimeObjList)+len(unstructObjList) == 0 {
+			obj.skipped = append(obj.skipped, skippedResource{source: yamlSource, reason: "unable to decode the resource"})
+			continue
+		}
+		obj.convert(yamlSource, runtimeObjList, gvkList, unstructObjList, unstructGvkList)
+	}
+}
+
+/*
+Adds the CRDs (apiextensions.k8s.io/v1 CustomResourceDefinition) of the yaml-content to the CrdStructConverter,
+So that their Custom-Resources are converted to typed go-structs (Needs to be called before convertYaml)
+*/
+func (obj *resourceConverter) addCrds(yamlSource string, data []byte) {
+	for _, doc := range strings.Split(string(data), "\n---") {
+		if isEmptyYamlDocument(doc) {
+			continue
+		}
+		unstructObject, gvk, err := unstructuredDecode([]byte(doc))
+		if err != nil || gvk.Group != "apiextensions.k8s.io" || gvk.Kind != "CustomResourceDefinition" {
+			continue
+		}
+		if err := obj.crdStructConverterObj.AddCrd(*unstructObject); err != nil {
+			logrus.Warn("Unable to generate typed go-structs from the CRD (Its Custom-Resources Would Be Treated as Third Party Kind)| Source : ", yamlSource, "| Error : ", err)
+		}
+	}
+}
+
+/*
+Loads the Go-Types Config-File (--go-types), So that the configured Third-Party Kinds are converted to the Go-Types of their published Go-Packages
+*/
+func (obj *resourceConverter) addGoTypes(configFile string) error {
+	if err := obj.goTypeConverterObj.LoadConfig(configFile); err != nil {
+		return err
+	}
+	obj.jsonStringConverterObj.AddEnums(obj.goTypeConverterObj.GetEnums())
+	return nil
+}
+
+/*
+Returns the install-weight of the resource (installWeightAnnotation), 0 if the annotation is not present or is not an integer
+*/
+func getInstallWeight(resource runtime.Object) int {
+	metaObj, ok := resource.(metav1.Object)
+	if !ok {
+		return 0
+	}
+	weightAnnotation, ok := metaObj.GetAnnotations()[installWeightAnnotation]
+	if !ok {
+		return 0
+	}
+	weight, err := strconv.Atoi(strings.TrimSpace(weightAnnotation))
+	if err != nil {
+		logrus.Warn(fmt.Sprintf("Invalid %s annotation (Using 0)| Name : %s| Value : %s", installWeightAnnotation, metaObj.GetName(), weightAnnotation))
+		return 0
+	}
+	return weight
+}
+
+/*
+Adds the go-code of the resource to gocodes, The go-codes of a GVK are kept sorted by the name & namespace of the resources,
+So that the generated go-code doesn't depend on the order of the input (Resources having the same name & namespace keep their input order)
+*/
+func (obj *resourceConverter) addGoCode(gvk schema.GroupVersionKind, resource runtime.Object, gocode string) {
+	key := ""
+	if metaObj, ok := resource.(metav1.Object); ok {
+		key = metaObj.GetName() + "/" + metaObj.GetNamespace()
+	}
+	keys := obj.gocodeKeys[gvk]
+	index := sort.Search(len(keys), func(i int) bool { return keys[i] > key })
+	obj.gocodeKeys[gvk] = append(keys[:index], append([]string{key}, keys[index:]...)...)
+	obj.gocodes[gvk] = append(obj.gocodes[gvk][:index], append([]string{gocode}, obj.gocodes[gvk][index:]...)...)
+	obj.installWeights[gvk] = append(obj.installWeights[gvk][:index], append([]int{getInstallWeight(resource)}, obj.installWeights[gvk][index:]...)...)
+}
+
+/*
+Returns the upper-cased first letter of the name, Example: v2beta2 --> V2beta2, apps --> Apps
+*/
+func capitalize(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+/*
+Returns the Resource-Type (Suffix of Get<Resource-Type>()) of each GVK of gocodes:
+The Kind, If only one GVK of gocodes has that Kind, Otherwise the Kind suffixed with its Version (Example: HorizontalPodAutoscalerV2),
+And If the Kind & Version are also not unique, The Kind suffixed with its Group & Version (Example: DatabaseExampleV1, The core-group is written as Core)
+*/
+func (obj *resourceConverter) getResourceTypes() map[schema.GroupVersionKind]string {
+	kindCount := map[string]int{}
+	kindVersionCount := map[string]int{}
+	for gvk := range obj.gocodes {
+		kindCount[gvk.Kind]++
+		kindVersionCount[gvk.Kind+"/"+gvk.Version]++
+	}
+	resourceTypes := map[schema.GroupVersionKind]string{}
+	for gvk := range obj.gocodes {
+		switch {
+		case kindCount[gvk.Kind] == 1:
+			resourceTypes[gvk] = gvk.Kind
+		case kindVersionCount[gvk.Kind+"/"+gvk.Version] == 1:
+			resourceTypes[gvk] = gvk.Kind + capitalize(gvk.Version)
+		default:
+			group := strings.Split(gvk.Group, ".")[0] // Example: example.nephio.org --> example
+			if group == "" {
+				group = "core"
+			}
+			resourceTypes[gvk] = gvk.Kind + capitalize(group) + capitalize(gvk.Version)
 		}
+	}
+	return resourceTypes
+}
 
-		for i := 0; i < len(unstructObjList); i++ {
-			gocode := unstructStringConverterObj.Convert(unstructObjList[i])
-			gocodes[unstructGvkList[i].Kind] = append(gocodes[unstructGvkList[i].Kind], gocode)
-			logrus.Info("\t Converting Unstructured to String Completed ")
+/*
+Generates the Go-File(s) from the gocodes, Keyed by the Resource-Type of each GVK (See getResourceTypes),
+Along with the install-weights, type-definitions and the kinds of the Resource-Types
+The generated Go-File(s) are type-checked as one package, If the type-check is enabled
+*/
+func (obj *resourceConverter) generateGoFile(goFileObj *common.GoFile) error {
+	gocodes := map[string][]string{}
+	goFileObj.InstallWeights = map[string][]int{}
+	goFileObj.TypeDefinitions = map[string]string{}
+	goFileObj.ResourceKinds = map[string]string{}
+	for gvk, resourceType := range obj.getResourceTypes() {
+		gocodes[resourceType] = obj.gocodes[gvk]
+		goFileObj.InstallWeights[resourceType] = obj.installWeights[gvk]
+		goFileObj.ResourceKinds[resourceType] = gvk.Kind
+		if typeDefinitions, ok := obj.typeDefinitions[gvk]; ok {
+			goFileObj.TypeDefinitions[resourceType] = typeDefinitions
 		}
 	}
-	logrus.Info("----------------- Writing GO Code ---------------------------------")
-	goFileObj.Generate(gocodes)
-	goFileObj.WriteToFile()
-	logrus.Info("----------------- Program Run Successful| Summary ---------------------------------")
-	for resourceType, resourceList := range gocodes {
-		logrus.Info(resourceType, "\t\t |", len(resourceList))
+	if err := goFileObj.Generate(gocodes); err != nil {
+		return err
 	}
-	err = os.RemoveAll("temp")
+	if obj.goCodeCheckerObj != nil {
+		// The go-code of each resource compiles, But the assembled go-file(s) are type-checked as well (Example: The return-types of Get<Kind>())
+		if err := obj.goCodeCheckerObj.CheckFiles(goFileObj.Files); err != nil {
+			logrus.Error("Type-Check of the Generated Go-File Failed | ", err)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Enables the type-check (GoCodeChecker) of the go-code of each resource and of the generated go-file, The resources whose go-code doesn't compile are skipped
+The k8s API packages are compiled into the sdk, Only the Go-Packages of the Go-Types are resolved in their goModuleDir, Therefore addGoTypes needs to be called before
+Returns an error If the Go-Packages of the Go-Types can't be loaded (Example: The go-toolchain is not installed)
+*/
+func (obj *resourceConverter) enableTypeCheck() error {
+	goCodeCheckerObj := &common.GoCodeChecker{}
+	if err := goCodeCheckerObj.Intialise(obj.goTypeConverterObj.GetGoModuleDir(), obj.goTypeConverterObj.GetImports()); err != nil {
+		logrus.Error("Unable to load the Go-Packages for the Type-Check | ", err)
+		return fmt.Errorf("unable to load the go-packages for the type-check (The goModuleDir of --go-types needs to be a go-module requiring k8s.io/api, Otherwise disable it using --type-check=false)| %w", err)
+	}
+	obj.goCodeCheckerObj = goCodeCheckerObj
+	return nil
+}
+
+/*
+Type-Checks the go-code of a resource along with the type-definitions of its kind, If the type-check is enabled
+*/
+func (obj *resourceConverter) checkGoCode(gocode string, typeDefinitions string) error {
+	if obj.goCodeCheckerObj == nil {
+		return nil
+	}
+	return obj.goCodeCheckerObj.Check(gocode, typeDefinitions)
+}
+
+/*
+Enables the round-trip verification (GoCodeEvaluator) of the go-code of each resource
+*/
+func (obj *resourceConverter) enableRoundTrip() {
+	obj.goCodeEvaluatorObj = &common.GoCodeEvaluator{}
+	obj.goCodeEvaluatorObj.Intialise()
+}
+
+/*
+Evaluates the go-code of a resource and compares the resulting object with the decoded resource, If the round-trip verification is enabled
+*/
+func (obj *resourceConverter) verifyRoundTrip(yamlSource string, gvk schema.GroupVersionKind, resource runtime.Object, gocode string) {
+	if obj.goCodeEvaluatorObj == nil {
+		return
+	}
+	diffs, dropped, err := obj.goCodeEvaluatorObj.Verify(gocode, resource)
 	if err != nil {
-		logrus.Warn("Failed to delete the Temp Directory| Error | ", err)
-		logrus.Warn("Manual Delete Advised| temp")
+		diffs = []string{err.Error()}
 	}
+	obj.roundTrips = append(obj.roundTrips, roundTripResult{source: yamlSource, gvk: gvk, name: getResourceName(resource), diffs: diffs, dropped: dropped, verified: true})
+}
+
+/*
+Same as verifyRoundTrip, For the typed go-structs of the CRDs & the Go-Types, Whose data-types are not known at runtime
+getGoTypes: Returns their data-types built at runtime (CrdStructConverter.GetGoTypes or GoTypeConverter.GetGoTypes),
+The resource is recorded as not verified, If they can't be built (Example: Recursive Go-Types)
+*/
+func (obj *resourceConverter) verifyTypedRoundTrip(yamlSource string, gvk schema.GroupVersionKind, resource runtime.Object, gocode string,
+	getGoTypes func(schema.GroupVersionKind) (map[string]reflect.Type, error)) {
+	if obj.goCodeEvaluatorObj == nil {
+		return
+	}
+	goTypes, err := getGoTypes(gvk)
+	if err != nil {
+		obj.roundTrips = append(obj.roundTrips, roundTripResult{source: yamlSource, gvk: gvk, name: getResourceName(resource), reason: err.Error()})
+		return
+	}
+	diffs, dropped, err := obj.goCodeEvaluatorObj.WithGoTypes(goTypes).Verify(gocode, resource)
+	if err != nil {
+		diffs = []string{err.Error()}
+	}
+	obj.roundTrips = append(obj.roundTrips, roundTripResult{source: yamlSource, gvk: gvk, name: getResourceName(resource), diffs: diffs, dropped: dropped, verified: true})
+}
+
+/*
+Returns the name of the resource, "" if it doesn't have ObjectMeta
+*/
+func getResourceName(resource runtime.Object) string {
+	if metaObj, ok := resource.(metav1.Object); ok {
+		return metaObj.GetName()
+	}
+	return ""
+}
 
+func (obj *resourceConverter) skip(yamlSource string, runtimeObj runtime.Object, gvk schema.GroupVersionKind, err error) {
+	obj.skipped = append(obj.skipped, skippedResource{source: yamlSource, gvk: gvk, name: getResourceName(runtimeObj), reason: err.Error()})
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		logrus.Error(err)
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
+		os.Exit(1)
+	}
 }
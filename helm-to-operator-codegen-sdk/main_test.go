@@ -17,13 +17,26 @@ limitations under the License.
 package main
 
 import (
-	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"helm_to_controller/packages/common"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/fn/framework"
+	k8syaml "sigs.k8s.io/yaml"
+
+	// controller-runtime is only required to compile the generated go-code (See TestGeneratedCodeCompiles)
+	_ "sigs.k8s.io/controller-runtime/pkg/client"
+	_ "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func setLogLevelFatal() {
@@ -34,27 +47,6 @@ func setLogLevelFatal() {
 	logrus.SetLevel(ll)
 }
 
-func checkIfHelmInstalled() error {
-	cmdStruct := exec.Command("helm", "version")
-	stderr, _ := cmdStruct.StderrPipe() // Intialising a Pipe to read error stream
-	if err := cmdStruct.Start(); err != nil {
-		fmt.Println("Unable to Start Cmd Pipe to check Helm-Install")
-		return err
-	}
-
-	scanner := bufio.NewScanner(stderr)
-	helmCmdErr := ""
-	for scanner.Scan() {
-		helmCmdErr += scanner.Text()
-	}
-	if len(helmCmdErr) > 0 {
-		fmt.Println("Error while checking the Helm Version|", helmCmdErr)
-		return fmt.Errorf(helmCmdErr)
-	}
-	// fmt.Println("helm is installed")
-	return nil
-}
-
 /*
 Tests for Runtime-OBject Way of Handling KRM-Object
 */
@@ -89,14 +81,8 @@ func TestHandleSingleYamlCR(t *testing.T) {
 
 func TestMainFunc(t *testing.T) {
 	setLogLevelFatal()
-	err := checkIfHelmInstalled()
-	if err != nil {
-		fmt.Println("Helm Not Installed Detected| Aborting the current test")
-		return
-	}
 	saveCmdArgs := os.Args
-	os.Args = []string{"main.go", "common/tests/test-helmCharts/hello-world/", "abc"}
-	os.Args = append(os.Args, saveCmdArgs...)
+	os.Args = []string{"main.go", "generate", "common/tests/test-helmCharts/hello-world/", "--namespace", "abc", "--log-level", "fatal"}
 	main()
 	os.Args = saveCmdArgs
 	// According to the temp directory should have been deleted, If it is not Then The flow has encountered as error
@@ -113,3 +99,655 @@ func TestMainFunc(t *testing.T) {
 	_ = os.Remove("outputs/generated_code.go")
 
 }
+
+func TestGenerateFlags(t *testing.T) {
+	opts := &generateOptions{}
+	cmd := &cobra.Command{}
+	addGenerateFlags(cmd, opts)
+	input := []string{"-f", "a.yaml", "--values=b.yaml", "-n", "myns", "--set", "x=1", "--set-string=y=2", "--set-file", "z=c.txt",
+		"--repo", "https://charts.example.com", "--version=1.2.3", "--digest", "sha256:abc", "--registry-config", "config.json", "--cache-dir=cache",
+		"--username", "user", "--password=secret",
+		"--release-name", "amf", "--output-dir", "controllers", "--file-name", "amf.go", "--split-by-kind", "--package", "amf", "--crd", "crds/"}
+	if err := cmd.ParseFlags(input); err != nil {
+		t.Fatalf("Unable to parse the flags | Error %v", err)
+	}
+	expected := common.HelmYamlConvertor{Namespace: "myns", ReleaseName: "amf", ValueFiles: []string{"a.yaml", "b.yaml"}, Values: []string{"x=1"},
+		StringValues: []string{"y=2"}, FileValues: []string{"z=c.txt"}, ChartRepoURL: "https://charts.example.com",
+		ChartVersion: "1.2.3", ChartDigest: "sha256:abc", RegistryConfig: "config.json", CacheDir: "cache",
+		RepoUsername: "user", RepoPassword: "secret"}
+	if !reflect.DeepEqual(opts.helmYamlConvertor, expected) {
+		t.Errorf("Helm-Flags Parsed Incorrectly | Expected %v | Got %v", expected, opts.helmYamlConvertor)
+	}
+	if opts.outputDir != "controllers" || opts.fileName != "amf.go" || !opts.splitByKind || opts.packageName != "amf" || opts.input != "inputs" ||
+		!reflect.DeepEqual(opts.crdPaths, []string{"crds/"}) {
+		t.Errorf("Flags Parsed Incorrectly | Got %+v", opts)
+	}
+
+	if err := cmd.ParseFlags([]string{"--set"}); err == nil {
+		t.Errorf("Expected error for flag without value, Got nil")
+	}
+}
+
+/*
+Executes the root-command with the args, Returns the error & the output written to stdout
+*/
+func executeCommand(args []string, stdin string) (string, error) {
+	var output bytes.Buffer
+	cmd := newRootCommand()
+	cmd.SetArgs(append(args, "--log-level=fatal"))
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&output)
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+func TestGenerateWithConfigFile(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "controllers")
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	config := fmt.Sprintf("input: common/tests/test-helmCharts/hello-world\nnamespace: from-config\npackage: helloworld\noutput-dir: %s\nfile-name: generated.go\nset: [replicaCount=3]\n", outputDir)
+	if err := os.WriteFile(configFile, []byte(config), 0600); err != nil {
+		t.Fatalf("Unable to write the config-file | Error %v", err)
+	}
+	if _, err := executeCommand([]string{"generate", "--config", configFile, "--namespace", "from-flag"}, ""); err != nil {
+		t.Fatalf("Generate with config-file failed | Error %v", err)
+	}
+	generatedCode, err := os.ReadFile(filepath.Join(outputDir, "generated.go"))
+	if err != nil {
+		t.Fatalf("Generated Go-File doesn't exist in %s", outputDir)
+	}
+	for _, expected := range []string{"package helloworld", `namespaceProvided := "from-flag"`, "Replicas: int32Ptr(3)"} {
+		if !strings.Contains(string(generatedCode), expected) {
+			t.Errorf("'%s' Not Found in generated code", expected)
+		}
+	}
+
+	if _, err := executeCommand([]string{"generate", "--config", configFile, "--unknown"}, ""); err == nil {
+		t.Errorf("Expected error for unknown flag, Got nil")
+	}
+	_ = os.WriteFile(configFile, []byte("unknown-key: abc\n"), 0600)
+	if _, err := executeCommand([]string{"generate", "--config", configFile}, ""); err == nil {
+		t.Errorf("Expected error for unknown key in config-file, Got nil")
+	}
+
+	// The log-level of the config-file is applied (Unless provided on the command-line)
+	defer setLogLevelFatal()
+	_ = os.WriteFile(configFile, []byte("log-level: error\n"), 0600)
+	cmd := newRootCommand()
+	cmd.SetArgs([]string{"verify", "--config", configFile, "common/tests/test-yamls/deployment.yaml"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Verify with config-file failed | Error %v", err)
+	}
+	if logrus.GetLevel() != logrus.ErrorLevel {
+		t.Errorf("Log-Level of the config-file isn't applied | Expected %v | Got %v", logrus.ErrorLevel, logrus.GetLevel())
+	}
+	_ = os.WriteFile(configFile, []byte("log-level: verbose\n"), 0600)
+	if _, err := executeCommand([]string{"verify", "--config", configFile, "common/tests/test-yamls/deployment.yaml"}, ""); err != nil {
+		t.Errorf("Log-Level of the command-line should take precedence over the config-file | Error %v", err)
+	}
+	cmd = newRootCommand()
+	cmd.SetArgs([]string{"verify", "--config", configFile, "common/tests/test-yamls/deployment.yaml"})
+	if err := cmd.Execute(); err == nil {
+		t.Errorf("Expected error for invalid log-level in config-file, Got nil")
+	}
+}
+
+func TestVerifyWithSkippedResources(t *testing.T) {
+	invalidDeployment := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: invalid\nspec:\n  replicas: invalid-replicas\n"
+	validService := "apiVersion: v1\nkind: Service\nmetadata:\n  name: valid\n"
+	if _, err := executeCommand([]string{"verify", "-"}, validService); err != nil {
+		t.Errorf("Verify failed for valid resources | Error %v", err)
+	}
+	_, err := executeCommand([]string{"verify", "-"}, validService+"---\n"+invalidDeployment)
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != exitCodeResourcesSkipped {
+		t.Errorf("Expected exit-code %d for skipped resources | Got %v", exitCodeResourcesSkipped, err)
+	}
+	if _, err := executeCommand([]string{"verify", "-", "--allow-skipped"}, validService+"---\n"+invalidDeployment); err != nil {
+		t.Errorf("Verify with --allow-skipped failed | Error %v", err)
+	}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	setLogLevelFatal()
+	validService := "apiVersion: v1\nkind: Service\nmetadata:\n  name: valid\nspec:\n  ports:\n  - port: 80\n"
+	thirdPartyResource := "apiVersion: example.com/v1\nkind: Foo\nmetadata:\n  name: foo\nspec:\n  count: 0\n  enabled: false\n"
+	if _, err := executeCommand([]string{"verify", "-", "--round-trip", "--type-check=false"}, validService+"---\n"+thirdPartyResource); err != nil {
+		t.Errorf("Round-Trip Verification failed for reproducible resources | Error %v", err)
+	}
+
+	// Helm-Specific-Labels are dropped by the RuntimeJsonConverter on purpose, Therefore they are reported as intentionally dropped
+	helmLabels := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: zero\n  labels:\n    app: zero\n    helm.sh/chart: chart-0.1.0\n" +
+		"spec:\n  replicas: 0\n  template:\n    metadata:\n      labels:\n        app.kubernetes.io/managed-by: Helm\n"
+	opts := generateOptions{input: "-", stdin: strings.NewReader(validService + "---\n" + helmLabels), roundTrip: true}
+	_, resourceConverterObj, err := opts.generate()
+	if err != nil {
+		t.Fatalf("Generate with round-trip failed | Error %v", err)
+	}
+	expectedDropped := map[string][]string{
+		"valid": nil,
+		"zero": {`metadata.labels.helm.sh/chart: expected "chart-0.1.0", got <missing>`,
+			`spec.template.metadata.labels: expected {"app.kubernetes.io/managed-by":"Helm"}, got <missing>`},
+	}
+	for _, roundTrip := range resourceConverterObj.roundTrips {
+		if !roundTrip.verified || len(roundTrip.diffs) != 0 {
+			t.Errorf("Round-Trip of %s should be verified without differences | Got %v", roundTrip.name, roundTrip.diffs)
+		}
+		if !reflect.DeepEqual(roundTrip.dropped, expectedDropped[roundTrip.name]) {
+			t.Errorf("Dropped Helm-Specific-Labels of %s are not as expected | Expected %v | Got %v", roundTrip.name, expectedDropped[roundTrip.name], roundTrip.dropped)
+		}
+	}
+	if _, err := executeCommand([]string{"verify", "common/tests/test-helmCharts/hello-world", "--round-trip", "--type-check=false"}, ""); err != nil {
+		t.Errorf("Round-Trip Verification of the hello-world chart failed | Error %v", err)
+	}
+
+	// The typed go-structs of the CRDs & the Go-Types are evaluated using their data-types built at runtime
+	for _, goTypesConfig := range []string{"", "common/tests/test-gotypes/go-types.yaml"} {
+		opts := generateOptions{input: "common/tests/test-crds/custom-resources.yaml", crdPaths: []string{"common/tests/test-crds/crds.yaml"},
+			goTypesConfig: goTypesConfig, roundTrip: true}
+		_, resourceConverterObj, err := opts.generate()
+		if err != nil {
+			t.Fatalf("Generate with round-trip failed | Error %v", err)
+		}
+		if len(resourceConverterObj.roundTrips) != 3 {
+			t.Errorf("Expected 3 Round-Trips (--go-types %q) | Got %d", goTypesConfig, len(resourceConverterObj.roundTrips))
+		}
+		for _, roundTrip := range resourceConverterObj.roundTrips {
+			if !roundTrip.verified || len(roundTrip.diffs) != 0 {
+				t.Errorf("Round-Trip of %s (--go-types %q) should be verified without differences | Got %v %s", roundTrip.name, goTypesConfig, roundTrip.diffs, roundTrip.reason)
+			}
+		}
+	}
+
+	var exitErr *exitError
+	resourceConverterObj.roundTrips = append(resourceConverterObj.roundTrips, roundTripResult{name: "differing", diffs: []string{"spec.replicas: expected 0, got <missing>"}, verified: true})
+	if err := checkRoundTrips(resourceConverterObj); !errors.As(err, &exitErr) || exitErr.code != exitCodeRoundTripDiffers {
+		t.Errorf("Expected exit-code %d for differing resources | Got %v", exitCodeRoundTripDiffers, err)
+	}
+}
+
+func TestGenerateInstallOrder(t *testing.T) {
+	setLogLevelFatal()
+	deployment := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n"
+	configMap := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"
+	serviceAccount := "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: sa\n  annotations:\n    nephio.org/install-weight: \"1\"\n"
+	opts := generateOptions{input: "-", stdin: strings.NewReader(deployment + "---\n" + serviceAccount + "---\n" + configMap)}
+	goFileObj, _, err := opts.generate()
+	if err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	// ConfigMap before Deployment (Same as of helm), ServiceAccount at last (install-weight 1)
+	expected := regexp.MustCompile(`return \[\]client.Object{\s*configMapList\[0\],\s*deploymentList\[0\],\s*serviceAccountList\[0\],\s*}`)
+	if !expected.MatchString(goFileObj.FileContent) {
+		t.Errorf("Resources are not in the install-order | Got %s", goFileObj.FileContent)
+	}
+}
+
+func TestGenerateWithValuesTypes(t *testing.T) {
+	setLogLevelFatal()
+	opts := generateOptions{input: "common/tests/test-helmCharts/values-schema", valuesTypes: true, packageName: "valuesschema"}
+	goFileObj, _, err := opts.generate()
+	if err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	valuesTypes := goFileObj.Files["values_types.go"]
+	for _, expected := range []string{"package valuesschema", "type ValuesSchemaSpec struct {", "\"encoding/json\""} {
+		if !strings.Contains(valuesTypes, expected) {
+			t.Errorf("'%s' Not Found in values_types.go | Got %s", expected, valuesTypes)
+		}
+	}
+	// Not a helm-chart: values_types.go is not generated
+	opts = generateOptions{input: "-", stdin: strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"), valuesTypes: true}
+	if goFileObj, _, err = opts.generate(); err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	if _, found := goFileObj.Files["values_types.go"]; found {
+		t.Errorf("values_types.go should not be generated for the manifests")
+	}
+}
+
+func TestGenerateWithTypeCheck(t *testing.T) {
+	setLogLevelFatal()
+	// null can't be written by the UnstructStringConverter, Therefore the go-code of the resource doesn't compile
+	nullValueResource := "apiVersion: example.com/v1\nkind: Foo\nmetadata:\n  name: null-value\nspec:\n  key: null\n"
+	validService := "apiVersion: v1\nkind: Service\nmetadata:\n  name: valid\n"
+	opts := generateOptions{input: "-", stdin: strings.NewReader(validService + "---\n" + nullValueResource), typeCheck: true}
+	goFileObj, resourceConverterObj, err := opts.generate()
+	if err != nil {
+		t.Fatalf("Generate with type-check failed | Error %v", err)
+	}
+	if len(resourceConverterObj.skipped) != 1 || resourceConverterObj.skipped[0].name != "null-value" || resourceConverterObj.skipped[0].source != "<stdin>" ||
+		!strings.Contains(resourceConverterObj.skipped[0].reason, `line 9 ("key": ,)`) {
+		t.Fatalf("Resource whose go-code doesn't compile should be skipped | Got %v", resourceConverterObj.skipped)
+	}
+	if strings.Contains(goFileObj.FileContent, "GetFoo") || !strings.Contains(goFileObj.FileContent, "GetService") {
+		t.Errorf("Only the go-code which compiles should be generated | Got %s", goFileObj.FileContent)
+	}
+
+	// The k8s API packages are compiled into the sdk, Therefore the type-check doesn't need a go-module in the current directory
+	chartDir, err := filepath.Abs("common/tests/test-helmCharts/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(workDir) //nolint:errcheck
+	if _, err := executeCommand([]string{"generate", chartDir}, ""); err != nil {
+		t.Errorf("Generate with type-check outside a go-module failed | Error %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("outputs", "generated_code.go")); err != nil {
+		t.Errorf("Generated Go-File not found | Error %v", err)
+	}
+}
+
+func TestGenerateWithEmptyStructs(t *testing.T) {
+	setLogLevelFatal()
+	emptyDirPod := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: empty-dir\nspec:\n  containers:\n  - name: app\n    image: busybox\n  volumes:\n  - name: tmp\n    emptyDir: {}\n"
+	opts := generateOptions{input: "-", stdin: strings.NewReader(emptyDirPod), typeCheck: true}
+	goFileObj, resourceConverterObj, err := opts.generate()
+	if err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	if len(resourceConverterObj.skipped) != 0 {
+		t.Fatalf("No resource should be skipped | Got %v", resourceConverterObj.skipped)
+	}
+	if !strings.Contains(goFileObj.FileContent, "EmptyDir: &corev1.EmptyDirVolumeSource{}") {
+		t.Errorf("The empty emptyDir should be generated as &corev1.EmptyDirVolumeSource{} | Got %s", goFileObj.FileContent)
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	setLogLevelFatal()
+	generate := func(opts generateOptions) string {
+		opts.typeCheck = false
+		goFileObj, _, err := opts.generate()
+		if err != nil {
+			t.Fatalf("Generate failed | Error %v", err)
+		}
+		return goFileObj.FileContent
+	}
+
+	// Two runs over the same input produce byte-identical output
+	for _, input := range []string{"common/tests/test-helmCharts/hello-world", "common/tests/test-crds", "common/tests/test-yamls"} {
+		firstRun, secondRun := generate(generateOptions{input: input}), generate(generateOptions{input: input})
+		if firstRun != secondRun {
+			t.Errorf("Two runs over %s produced different output", input)
+		}
+	}
+
+	// The resources of a kind are sorted by name, Irrespective of the input order
+	serviceA := "apiVersion: v1\nkind: Service\nmetadata:\n  name: service-a\n"
+	serviceB := "apiVersion: v1\nkind: Service\nmetadata:\n  name: service-b\n"
+	deployment := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: amf\nspec:\n  template:\n    spec:\n      containers:\n      - name: amf\n        image: amf:v1\n"
+	inOrder := generate(generateOptions{input: "-", stdin: strings.NewReader(serviceA + "---\n" + deployment + "---\n" + serviceB)})
+	reversed := generate(generateOptions{input: "-", stdin: strings.NewReader(serviceB + "---\n" + deployment + "---\n" + serviceA)})
+	if inOrder != reversed {
+		t.Errorf("Output depends on the order of the input resources")
+	}
+	if !strings.Contains(inOrder, `"service-a"`) || strings.Index(inOrder, `"service-a"`) > strings.Index(inOrder, `"service-b"`) {
+		t.Errorf("Resources of a kind should be sorted by name")
+	}
+	// The fields are written in the declaration order of the struct (corev1.Container: Name, Image, ...)
+	if !regexp.MustCompile(`Name:\s+"amf",\s+Image:\s+"amf:v1",`).MatchString(inOrder) {
+		t.Errorf("Fields should be written in the declaration order | Got %s", inOrder)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	outputDir := t.TempDir()
+	if _, err := executeCommand([]string{"generate", "common/tests/test-yamls/deployment.yaml", "-o", outputDir}, ""); err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	if diff, err := executeCommand([]string{"diff", "common/tests/test-yamls/deployment.yaml", "-o", outputDir}, ""); err != nil || diff != "" {
+		t.Errorf("Expected no diff for up-to-date Go-File | Got %s | Error %v", diff, err)
+	}
+
+	diff, err := executeCommand([]string{"diff", "common/tests/test-yamls/deployment.yaml", "-o", outputDir, "--package", "other"}, "")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != exitCodeOutputDiffers {
+		t.Errorf("Expected exit-code %d for outdated Go-File | Got %v", exitCodeOutputDiffers, err)
+	}
+	if !strings.Contains(diff, "-package controller") || !strings.Contains(diff, "+package other") {
+		t.Errorf("Unified-diff is not as expected | Got %s", diff)
+	}
+}
+
+func TestListKinds(t *testing.T) {
+	output, err := executeCommand([]string{"list-kinds"}, "")
+	if err != nil {
+		t.Fatalf("List-Kinds failed | Error %v", err)
+	}
+	if len(strings.Fields(output)) != len(runtimeSupportKinds) || !strings.Contains(output, "Deployment") {
+		t.Errorf("Supported kinds are not as expected | Got %s", output)
+	}
+
+	output, err = executeCommand([]string{"list-kinds", "common/tests/test-yamls"}, "")
+	if err != nil {
+		t.Fatalf("List-Kinds failed | Error %v", err)
+	}
+	for _, expected := range []string{"apps/v1/Deployment", "typed", "unstructured"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("'%s' Not Found in list-kinds output | Got %s", expected, output)
+		}
+	}
+}
+
+func TestIsManifestInput(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"common/tests/test-yamls", true},
+		{"common/tests/test-yamls/service.json", true},
+		{"common/tests/test-helmCharts/hello-world", false},
+		{"common/tests/non-existing-dir", false},
+	}
+	for _, test := range tests {
+		result := isManifestInput(test.input)
+		if result != test.expected {
+			t.Errorf("IsManifestInput Failed | Input %s | Expected %v | Got %v", test.input, test.expected, result)
+		}
+	}
+}
+
+/*
+Tests for Plain Kubernetes Manifests as Input (Bypassing Helm)
+*/
+func TestMainFuncWithManifestDirectory(t *testing.T) {
+	setLogLevelFatal()
+	saveCmdArgs := os.Args
+	os.Args = []string{"main.go", "generate", "common/tests/test-yamls", "-n", "abc", "--log-level=fatal"}
+	main()
+	os.Args = saveCmdArgs
+
+	generatedCode, err := os.ReadFile("outputs/generated_code.go")
+	if err != nil {
+		t.Fatalf("Generated_code.go File doesn't exist| Failing this test")
+	}
+	for _, expected := range []string{"func GetDeployment()", "func GetService()", "func GetThirdPartyCR()"} {
+		if !strings.Contains(string(generatedCode), expected) {
+			t.Errorf("Function %s Not Found in generated code", expected)
+		}
+	}
+	_ = os.Remove("outputs/generated_code.go")
+}
+
+/*
+Tests for Built-in Kinds beyond core/apps/rbac (batch, networking, policy) and Api-Versions other than v1 (autoscaling/v2), which should be converted to typed go-structs
+The Kinds having multiple Api-Versions (autoscaling/v1 & autoscaling/v2) should have a Get<Kind><Version>() per Api-Version
+*/
+func TestMainFuncWithBuiltinKinds(t *testing.T) {
+	outputDir := t.TempDir()
+	if _, err := executeCommand([]string{"generate", "common/tests/test-builtin-kinds", "-o", outputDir}, ""); err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	generatedCode, err := os.ReadFile(filepath.Join(outputDir, "generated_code.go"))
+	if err != nil {
+		t.Fatalf("Generated_code.go File doesn't exist| Failing this test")
+	}
+	for _, expected := range []string{"func GetJob() []*batchv1.Job", "func GetCronJob() []*batchv1.CronJob", "func GetDaemonSet() []*appsv1.DaemonSet",
+		"func GetIngress() []*networkingv1.Ingress", "func GetNetworkPolicy() []*networkingv1.NetworkPolicy", "func GetPodDisruptionBudget() []*policyv1.PodDisruptionBudget",
+		"batchv1.ConcurrencyPolicy(\"Forbid\")", "func GetHorizontalPodAutoscalerV2() []*autoscalingv2.HorizontalPodAutoscaler",
+		"func GetHorizontalPodAutoscalerV1() []*autoscalingv1.HorizontalPodAutoscaler",
+		"AverageValue: ptr.To(resource.MustParse(\"500Mi\"))"} {
+		if !strings.Contains(string(generatedCode), expected) {
+			t.Errorf("%s Not Found in generated code", expected)
+		}
+	}
+}
+
+/*
+Compiles the generated go-code against controller-runtime (A test-only dependency of the sdk, See the blank imports),
+In a go-module requiring the same k8s API packages & controller-runtime as the sdk
+*/
+func TestGeneratedCodeCompiles(t *testing.T) {
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("The go toolchain is required to compile the generated go-code")
+	}
+	moduleDir := t.TempDir()
+	goMod, err := os.ReadFile("go.mod")
+	if err != nil {
+		t.Fatalf("Unable to read the go.mod | Error %v", err)
+	}
+	goMod = bytes.Replace(goMod, []byte("module helm_to_controller/packages"), []byte("module generated"), 1)
+	goSum, err := os.ReadFile("go.sum")
+	if err != nil {
+		t.Fatalf("Unable to read the go.sum | Error %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), goMod, 0600); err != nil {
+		t.Fatalf("Unable to write the go.mod | Error %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.sum"), goSum, 0600); err != nil {
+		t.Fatalf("Unable to write the go.sum | Error %v", err)
+	}
+
+	// Package-Name --> Input of the generate command
+	tests := map[string][]string{
+		"helloworld":   {"common/tests/test-helmCharts/hello-world"},
+		"builtinkinds": {"common/tests/test-builtin-kinds", "--split-by-kind"},
+		"crds":         {"common/tests/test-crds"},
+		"valuesschema": {"common/tests/test-helmCharts/values-schema", "--values-types"},
+	}
+	for packageName, input := range tests {
+		args := append([]string{"generate", "-o", filepath.Join(moduleDir, packageName), "--package", packageName}, input...)
+		if _, err := executeCommand(args, ""); err != nil {
+			t.Fatalf("Generate failed for %s | Error %v", packageName, err)
+		}
+	}
+	cmd := exec.Command(goBinary, "vet", "./...")
+	cmd.Dir = moduleDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("The generated go-code doesn't compile | Error %v | Output %s", err, output)
+	}
+}
+
+/*
+Tests for Custom-Resources whose CRD is in the input (or provided by --crd), which should be converted to typed go-structs
+*/
+func TestMainFuncWithCrds(t *testing.T) {
+	outputDir := t.TempDir()
+	if _, err := executeCommand([]string{"generate", "common/tests/test-crds", "-o", outputDir}, ""); err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	generatedCode, err := os.ReadFile(filepath.Join(outputDir, "generated_code.go"))
+	if err != nil {
+		t.Fatalf("Generated_code.go File doesn't exist| Failing this test")
+	}
+	for _, expected := range []string{"type Database struct", "func GetDatabase() []*Database", "Users: []DatabaseSpecUsersItem{",
+		"type NetworkAttachmentDefinition struct", "func GetNetworkAttachmentDefinition() []*NetworkAttachmentDefinition",
+		"func GetCustomResourceDefinition() []*unstructured.Unstructured"} {
+		if !strings.Contains(string(generatedCode), expected) {
+			t.Errorf("%s Not Found in generated code", expected)
+		}
+	}
+
+	if _, err := executeCommand([]string{"generate", "common/tests/test-crds/custom-resources.yaml", "-o", outputDir, "--crd", "common/tests/test-crds/crds.yaml"}, ""); err != nil {
+		t.Fatalf("Generate with --crd failed | Error %v", err)
+	}
+	generatedCode, _ = os.ReadFile(filepath.Join(outputDir, "generated_code.go"))
+	if !strings.Contains(string(generatedCode), "func GetDatabase() []*Database") || strings.Contains(string(generatedCode), "GetCustomResourceDefinition") {
+		t.Errorf("Custom-Resources should be typed using the CRDs of --crd (without generating the CRDs)")
+	}
+
+	if _, err := executeCommand([]string{"generate", "common/tests/test-crds/custom-resources.yaml", "-o", outputDir}, ""); err != nil {
+		t.Fatalf("Generate failed | Error %v", err)
+	}
+	generatedCode, _ = os.ReadFile(filepath.Join(outputDir, "generated_code.go"))
+	if !strings.Contains(string(generatedCode), "func GetDatabase() []*unstructured.Unstructured") {
+		t.Errorf("Custom-Resources without CRD should be unstructured")
+	}
+
+	// The explicit Zero-Values of the Custom-Resources are kept
+	zeroValues := "apiVersion: example.com/v1beta1\nkind: Database\nmetadata:\n  name: zero\nspec:\n  enabled: false\n  count: 0\n"
+	if _, err := executeCommand([]string{"generate", "-", "-o", outputDir, "--crd", "common/tests/test-crds/crds.yaml"}, zeroValues); err != nil {
+		t.Fatalf("Generate with --crd failed | Error %v", err)
+	}
+	generatedCode, _ = os.ReadFile(filepath.Join(outputDir, "generated_code.go"))
+	if !strings.Contains(string(generatedCode), "Enabled: boolPtr(false)") || !strings.Contains(string(generatedCode), "Count:   int64Ptr(0)") {
+		t.Errorf("The explicit Zero-Values of the Custom-Resource should be kept | Got %s", generatedCode)
+	}
+}
+
+func TestMainFuncWithGoTypes(t *testing.T) {
+	outputDir := t.TempDir()
+	// The Go-Types of --go-types take precedence over the CRDs
+	if _, err := executeCommand([]string{"generate", "common/tests/test-crds/custom-resources.yaml", "-o", outputDir,
+		"--go-types", "common/tests/test-gotypes/go-types.yaml", "--crd", "common/tests/test-crds/crds.yaml"}, ""); err != nil {
+		t.Fatalf("Generate with --go-types failed | Error %v", err)
+	}
+	generatedCode, err := os.ReadFile(filepath.Join(outputDir, "generated_code.go"))
+	if err != nil {
+		t.Fatalf("Generated_code.go File doesn't exist| Failing this test")
+	}
+	for _, expected := range []string{"examplev1beta1 \"helm_to_controller/packages/common/tests/test-gotypes/apis/example/v1beta1\"",
+		"func GetDatabase() []*examplev1beta1.Database", "examplev1beta1.Role(\"read\")", "func GetNetworkAttachmentDefinition() []*NetworkAttachmentDefinition"} {
+		if !strings.Contains(string(generatedCode), expected) {
+			t.Errorf("%s Not Found in generated code", expected)
+		}
+	}
+	if strings.Contains(string(generatedCode), "type Database struct") {
+		t.Errorf("Type-Definitions should not be generated for the Kinds of --go-types")
+	}
+
+	output, err := executeCommand([]string{"list-kinds", "common/tests/test-crds/custom-resources.yaml", "--go-types", "common/tests/test-gotypes/go-types.yaml"}, "")
+	if err != nil {
+		t.Fatalf("List-Kinds with --go-types failed | Error %v", err)
+	}
+	if !strings.Contains(output, "typed (go-type)") {
+		t.Errorf("Kind of --go-types should be listed as typed (go-type) | Got %s", output)
+	}
+}
+
+func TestMainFuncWithStdin(t *testing.T) {
+	setLogLevelFatal()
+	stdinFile, err := os.Open("common/tests/test-yamls/deployment.yaml")
+	if err != nil {
+		t.Fatalf("Unable to open the test yaml | Error %v", err)
+	}
+	defer stdinFile.Close()
+	saveStdin, saveCmdArgs := os.Stdin, os.Args
+	os.Stdin = stdinFile
+	os.Args = []string{"main.go", "generate", "-", "-n", "abc", "--log-level=fatal"}
+	main()
+	os.Stdin, os.Args = saveStdin, saveCmdArgs
+
+	generatedCode, err := os.ReadFile("outputs/generated_code.go")
+	if err != nil {
+		t.Fatalf("Generated_code.go File doesn't exist| Failing this test")
+	}
+	if !strings.Contains(string(generatedCode), "func GetDeployment()") {
+		t.Errorf("Function GetDeployment Not Found in generated code")
+	}
+	_ = os.Remove("outputs/generated_code.go")
+}
+
+func TestMainFuncWithKustomization(t *testing.T) {
+	setLogLevelFatal()
+	saveCmdArgs := os.Args
+	os.Args = []string{"main.go", "generate", "common/tests/test-kustomize/overlays/site-a", "-n", "abc", "--log-level=fatal"}
+	main()
+	os.Args = saveCmdArgs
+
+	generatedCode, err := os.ReadFile("outputs/generated_code.go")
+	if err != nil {
+		t.Fatalf("Generated_code.go File doesn't exist| Failing this test")
+	}
+	for _, expected := range []string{"func GetDeployment()", "func GetConfigMap()", "site-a-nginx"} {
+		if !strings.Contains(string(generatedCode), expected) {
+			t.Errorf("'%s' Not Found in generated code", expected)
+		}
+	}
+	_ = os.Remove("outputs/generated_code.go")
+}
+
+/*
+Tests for KRM Function Mode (ResourceList as Input & Output)
+*/
+func TestRunKrmFunction(t *testing.T) {
+	setLogLevelFatal()
+	input := `apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+functionConfig:
+  apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: codegen-config
+  data:
+    name: amf-code
+    namespace: amf
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: amf-svc
+    annotations:
+      config.kubernetes.io/path: service.yaml
+      internal.config.kubernetes.io/path: service.yaml
+  spec:
+    ports:
+    - port: 80
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: amf-deploy
+    annotations:
+      config.kubernetes.io/path: deployment.yaml
+      internal.config.kubernetes.io/path: deployment.yaml
+  spec:
+    replicas: "invalid-replicas"
+- apiVersion: thirdparty.io/v1
+  kind: ThirdPartyCR
+  metadata:
+    name: amf-cr
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: amf-code
+    annotations:
+      nephio.org/generated-by: helm-to-operator-codegen-sdk
+  data:
+    generated_code.go: stale
+`
+	var output bytes.Buffer
+	if err := runKrmFunction(strings.NewReader(input), &output); err != nil {
+		t.Fatalf("KRM Function Failed | Error %v", err)
+	}
+	rl := framework.ResourceList{}
+	if err := k8syaml.Unmarshal(output.Bytes(), &rl); err != nil {
+		t.Fatalf("Unable to parse the output ResourceList | Error %v", err)
+	}
+	if len(rl.Items) != 4 {
+		t.Fatalf("Output ConfigMap should replace the stale one | Expected 4 Items | Got %d", len(rl.Items))
+	}
+	generatedCode := rl.Items[3].GetDataMap()["generated_code.go"]
+	for _, expected := range []string{"func GetService()", "func GetThirdPartyCR()", `namespaceProvided := "amf"`} {
+		if !strings.Contains(generatedCode, expected) {
+			t.Errorf("'%s' Not Found in generated code", expected)
+		}
+	}
+	if strings.Contains(generatedCode, "config.kubernetes.io") || strings.Contains(generatedCode, "stale") {
+		t.Errorf("Orchestrator annotations or stale output found in generated code")
+	}
+	if rl.Items[3].GetName() != "amf-code" || rl.Items[3].GetAnnotations()["config.kubernetes.io/local-config"] != "true" {
+		t.Errorf("Output ConfigMap is not as expected | Got %s", rl.Items[3].MustString())
+	}
+
+	warnings := 0
+	for _, result := range rl.Results {
+		if result.Severity == framework.Warning {
+			warnings++
+			if result.ResourceRef == nil || result.ResourceRef.Name != "amf-deploy" || result.File == nil || result.File.Path != "deployment.yaml" {
+				t.Errorf("Result of the skipped resource is not as expected | Got %v", result)
+			}
+		}
+	}
+	if warnings != 1 {
+		t.Errorf("Expected 1 warning for the skipped deployment | Got %d | Results %v", warnings, rl.Results)
+	}
+}